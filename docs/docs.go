@@ -15,6 +15,480 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/glossaries": {
+            "get": {
+                "description": "Show the glossaries, without their entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Show glossaries",
+                "operationId": "glossaries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GlossaryList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a glossary of a language pair, with its entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Create a glossary",
+                "operationId": "create-glossary",
+                "parameters": [
+                    {
+                        "description": "Glossary",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Glossary"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Glossary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/glossaries/{id}": {
+            "get": {
+                "description": "Show a glossary with its entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Show a glossary",
+                "operationId": "glossary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Glossary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a glossary or change its languages, keeping its entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Update a glossary",
+                "operationId": "update-glossary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Glossary, entries are ignored",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Glossary"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Glossary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a glossary and its entries",
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Delete a glossary",
+                "operationId": "delete-glossary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/glossaries/{id}/entries": {
+            "post": {
+                "description": "Add entries to a glossary; an entry replaces the entry with the same term",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Add glossary entries",
+                "operationId": "store-glossary-entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entries",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GlossaryEntries"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GlossaryEntries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/glossaries/{id}/entries/{entryId}": {
+            "delete": {
+                "description": "Delete an entry of a glossary",
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Delete a glossary entry",
+                "operationId": "delete-glossary-entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entry id",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/glossaries/{id}/import": {
+            "post": {
+                "description": "Add the terms of a CSV or TBX file to a glossary, in the languages of the glossary.\nCSV records are a term and its translation, after an optional header naming the columns\nterm (or the source language), translation (or the destination language), do_not_translate and case_sensitive",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "glossary"
+                ],
+                "summary": "Import glossary entries",
+                "operationId": "import-glossary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Glossary id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or TBX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or tbx; detected from the file extension by default",
+                        "name": "format",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Glossary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/languages": {
+            "get": {
+                "description": "Show the languages of the translation provider and the directions they are translated in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Show languages",
+                "operationId": "languages",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.LanguageList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translation/detect": {
+            "post": {
+                "description": "Detect the language of a text",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Detect language",
+                "operationId": "detect-language",
+                "parameters": [
+                    {
+                        "description": "Text to detect the language of",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DetectLanguage"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.LanguageDetection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/translation/do-translate": {
             "post": {
                 "description": "Translate a text",
@@ -50,13 +524,78 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Error"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/response.Error"
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translation/files": {
+            "post": {
+                "description": "Translate the values of a JSON, YAML, gettext PO or XLIFF 1.2/2.0 resource file, keeping keys, comments and plural forms,\nor the cues of a SubRip or WebVTT subtitle file, keeping cue identifiers and timings",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Translate a file",
+                "operationId": "translate-file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Resource file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source language",
+                        "name": "source",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Destination language",
+                        "name": "destination",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json, yaml, po, xliff, srt or vtt; detected from the file extension by default",
+                        "name": "format",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
@@ -86,7 +625,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/response.Error"
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
@@ -94,6 +633,118 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "entity.Glossary": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "vi"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlossaryEntry"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Product names"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "entity.GlossaryEntry": {
+            "type": "object",
+            "properties": {
+                "case_sensitive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "do_not_translate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "glossary_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "term": {
+                    "type": "string",
+                    "example": "sign in"
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "đăng nhập"
+                }
+            }
+        },
+        "entity.GlossaryList": {
+            "type": "object",
+            "properties": {
+                "glossaries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Glossary"
+                    }
+                }
+            }
+        },
+        "entity.Language": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "destination": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Portuguese (Brazil)"
+                },
+                "source": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "entity.LanguageDetection": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number",
+                    "example": 0.98
+                },
+                "language": {
+                    "type": "string",
+                    "example": "ru"
+                }
+            }
+        },
+        "entity.LanguageList": {
+            "type": "object",
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Language"
+                    }
+                }
+            }
+        },
         "entity.Translation": {
             "type": "object",
             "properties": {
@@ -101,6 +752,24 @@ const docTemplate = `{
                     "type": "string",
                     "example": "en"
                 },
+                "detected_language": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "detection_confidence": {
+                    "type": "number",
+                    "example": 0.98
+                },
+                "format": {
+                    "type": "string",
+                    "example": "text"
+                },
+                "glossary_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlossaryEntry"
+                    }
+                },
                 "original": {
                     "type": "string",
                     "example": "текст для перевода"
@@ -126,6 +795,85 @@ const docTemplate = `{
                 }
             }
         },
+        "request.DetectLanguage": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "текст для перевода"
+                }
+            }
+        },
+        "request.Glossary": {
+            "type": "object",
+            "required": [
+                "destination",
+                "name",
+                "source"
+            ],
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "vi"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.GlossaryEntry"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Product names"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "request.GlossaryEntries": {
+            "type": "object",
+            "required": [
+                "entries"
+            ],
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.GlossaryEntry"
+                    }
+                }
+            }
+        },
+        "request.GlossaryEntry": {
+            "type": "object",
+            "required": [
+                "term"
+            ],
+            "properties": {
+                "case_sensitive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "do_not_translate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "term": {
+                    "type": "string",
+                    "example": "sign in"
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "đăng nhập"
+                }
+            }
+        },
         "request.Translate": {
             "type": "object",
             "required": [
@@ -138,6 +886,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "en"
                 },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "html",
+                        "markdown"
+                    ],
+                    "example": "text"
+                },
                 "original": {
                     "type": "string",
                     "example": "текст для перевода"
@@ -148,12 +905,41 @@ const docTemplate = `{
                 }
             }
         },
-        "response.Error": {
+        "response.ErrorResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "code": {
                     "type": "string",
-                    "example": "message"
+                    "example": "VALIDATION_ERROR"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid input"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2026-02-22T21:58:00Z"
+                }
+            }
+        },
+        "response.GlossaryEntries": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GlossaryEntry"
+                    }
                 }
             }
         }
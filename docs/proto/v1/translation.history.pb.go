@@ -105,13 +105,15 @@ func (x *GetHistoryResponse) GetHistory() []*TranslationHistory {
 
 // Translation message structure.
 type TranslationHistory struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Destination   string                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
-	Original      string                 `protobuf:"bytes,3,opt,name=original,proto3" json:"original,omitempty"`
-	Translation   string                 `protobuf:"bytes,4,opt,name=translation,proto3" json:"translation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Source              string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination         string                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Original            string                 `protobuf:"bytes,3,opt,name=original,proto3" json:"original,omitempty"`
+	Translation         string                 `protobuf:"bytes,4,opt,name=translation,proto3" json:"translation,omitempty"`
+	DetectedLanguage    string                 `protobuf:"bytes,5,opt,name=detected_language,json=detectedLanguage,proto3" json:"detected_language,omitempty"`
+	DetectionConfidence float64                `protobuf:"fixed64,6,opt,name=detection_confidence,json=detectionConfidence,proto3" json:"detection_confidence,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *TranslationHistory) Reset() {
@@ -172,6 +174,269 @@ func (x *TranslationHistory) GetTranslation() string {
 	return ""
 }
 
+func (x *TranslationHistory) GetDetectedLanguage() string {
+	if x != nil {
+		return x.DetectedLanguage
+	}
+	return ""
+}
+
+func (x *TranslationHistory) GetDetectionConfidence() float64 {
+	if x != nil {
+		return x.DetectionConfidence
+	}
+	return 0
+}
+
+// Request message for GetLanguages.
+type GetLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguagesRequest) Reset() {
+	*x = GetLanguagesRequest{}
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguagesRequest) ProtoMessage() {}
+
+func (x *GetLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*GetLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_docs_proto_v1_translation_history_proto_rawDescGZIP(), []int{3}
+}
+
+// Response message for GetLanguages.
+type GetLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Languages     []*Language            `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguagesResponse) Reset() {
+	*x = GetLanguagesResponse{}
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguagesResponse) ProtoMessage() {}
+
+func (x *GetLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*GetLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_docs_proto_v1_translation_history_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLanguagesResponse) GetLanguages() []*Language {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+// Language message structure: a BCP-47 tag, its name and the directions it is translated in.
+type Language struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Source        bool                   `protobuf:"varint,3,opt,name=source,proto3" json:"source,omitempty"`
+	Destination   bool                   `protobuf:"varint,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Language) Reset() {
+	*x = Language{}
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Language) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Language) ProtoMessage() {}
+
+func (x *Language) ProtoReflect() protoreflect.Message {
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Language.ProtoReflect.Descriptor instead.
+func (*Language) Descriptor() ([]byte, []int) {
+	return file_docs_proto_v1_translation_history_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Language) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Language) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Language) GetSource() bool {
+	if x != nil {
+		return x.Source
+	}
+	return false
+}
+
+func (x *Language) GetDestination() bool {
+	if x != nil {
+		return x.Destination
+	}
+	return false
+}
+
+// Request message for DetectLanguage.
+type DetectLanguageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectLanguageRequest) Reset() {
+	*x = DetectLanguageRequest{}
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectLanguageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectLanguageRequest) ProtoMessage() {}
+
+func (x *DetectLanguageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectLanguageRequest.ProtoReflect.Descriptor instead.
+func (*DetectLanguageRequest) Descriptor() ([]byte, []int) {
+	return file_docs_proto_v1_translation_history_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DetectLanguageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Response message for DetectLanguage: a BCP-47 tag and the confidence of the provider, from 0 to 1.
+type DetectLanguageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectLanguageResponse) Reset() {
+	*x = DetectLanguageResponse{}
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectLanguageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectLanguageResponse) ProtoMessage() {}
+
+func (x *DetectLanguageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_docs_proto_v1_translation_history_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectLanguageResponse.ProtoReflect.Descriptor instead.
+func (*DetectLanguageResponse) Descriptor() ([]byte, []int) {
+	return file_docs_proto_v1_translation_history_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DetectLanguageResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *DetectLanguageResponse) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
 var File_docs_proto_v1_translation_history_proto protoreflect.FileDescriptor
 
 const file_docs_proto_v1_translation_history_proto_rawDesc = "" +
@@ -179,15 +444,34 @@ const file_docs_proto_v1_translation_history_proto_rawDesc = "" +
 	"'docs/proto/v1/translation.history.proto\x12\agrpc.v1\"\x13\n" +
 	"\x11GetHistoryRequest\"K\n" +
 	"\x12GetHistoryResponse\x125\n" +
-	"\ahistory\x18\x01 \x03(\v2\x1b.grpc.v1.TranslationHistoryR\ahistory\"\x8c\x01\n" +
+	"\ahistory\x18\x01 \x03(\v2\x1b.grpc.v1.TranslationHistoryR\ahistory\"\xec\x01\n" +
 	"\x12TranslationHistory\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12 \n" +
 	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x1a\n" +
 	"\boriginal\x18\x03 \x01(\tR\boriginal\x12 \n" +
-	"\vtranslation\x18\x04 \x01(\tR\vtranslation2T\n" +
+	"\vtranslation\x18\x04 \x01(\tR\vtranslation\x12+\n" +
+	"\x11detected_language\x18\x05 \x01(\tR\x10detectedLanguage\x121\n" +
+	"\x14detection_confidence\x18\x06 \x01(\x01R\x13detectionConfidence\"\x15\n" +
+	"\x13GetLanguagesRequest\"G\n" +
+	"\x14GetLanguagesResponse\x12/\n" +
+	"\tlanguages\x18\x01 \x03(\v2\x11.grpc.v1.LanguageR\tlanguages\"l\n" +
+	"\bLanguage\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\bR\x06source\x12 \n" +
+	"\vdestination\x18\x04 \x01(\bR\vdestination\"+\n" +
+	"\x15DetectLanguageRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"T\n" +
+	"\x16DetectLanguageResponse\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x01R\n" +
+	"confidence2\xf4\x01\n" +
 	"\vTranslation\x12E\n" +
 	"\n" +
-	"GetHistory\x12\x1a.grpc.v1.GetHistoryRequest\x1a\x1b.grpc.v1.GetHistoryResponseB\x0fZ\rdocs/proto/v1b\x06proto3"
+	"GetHistory\x12\x1a.grpc.v1.GetHistoryRequest\x1a\x1b.grpc.v1.GetHistoryResponse\x12K\n" +
+	"\fGetLanguages\x12\x1c.grpc.v1.GetLanguagesRequest\x1a\x1d.grpc.v1.GetLanguagesResponse\x12Q\n" +
+	"\x0eDetectLanguage\x12\x1e.grpc.v1.DetectLanguageRequest\x1a\x1f.grpc.v1.DetectLanguageResponseB\x0fZ\rdocs/proto/v1b\x06proto3"
 
 var (
 	file_docs_proto_v1_translation_history_proto_rawDescOnce sync.Once
@@ -201,21 +485,31 @@ func file_docs_proto_v1_translation_history_proto_rawDescGZIP() []byte {
 	return file_docs_proto_v1_translation_history_proto_rawDescData
 }
 
-var file_docs_proto_v1_translation_history_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_docs_proto_v1_translation_history_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_docs_proto_v1_translation_history_proto_goTypes = []any{
-	(*GetHistoryRequest)(nil),  // 0: grpc.v1.GetHistoryRequest
-	(*GetHistoryResponse)(nil), // 1: grpc.v1.GetHistoryResponse
-	(*TranslationHistory)(nil), // 2: grpc.v1.TranslationHistory
+	(*GetHistoryRequest)(nil),      // 0: grpc.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),     // 1: grpc.v1.GetHistoryResponse
+	(*TranslationHistory)(nil),     // 2: grpc.v1.TranslationHistory
+	(*GetLanguagesRequest)(nil),    // 3: grpc.v1.GetLanguagesRequest
+	(*GetLanguagesResponse)(nil),   // 4: grpc.v1.GetLanguagesResponse
+	(*Language)(nil),               // 5: grpc.v1.Language
+	(*DetectLanguageRequest)(nil),  // 6: grpc.v1.DetectLanguageRequest
+	(*DetectLanguageResponse)(nil), // 7: grpc.v1.DetectLanguageResponse
 }
 var file_docs_proto_v1_translation_history_proto_depIdxs = []int32{
 	2, // 0: grpc.v1.GetHistoryResponse.history:type_name -> grpc.v1.TranslationHistory
-	0, // 1: grpc.v1.Translation.GetHistory:input_type -> grpc.v1.GetHistoryRequest
-	1, // 2: grpc.v1.Translation.GetHistory:output_type -> grpc.v1.GetHistoryResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 1: grpc.v1.GetLanguagesResponse.languages:type_name -> grpc.v1.Language
+	0, // 2: grpc.v1.Translation.GetHistory:input_type -> grpc.v1.GetHistoryRequest
+	3, // 3: grpc.v1.Translation.GetLanguages:input_type -> grpc.v1.GetLanguagesRequest
+	6, // 4: grpc.v1.Translation.DetectLanguage:input_type -> grpc.v1.DetectLanguageRequest
+	1, // 5: grpc.v1.Translation.GetHistory:output_type -> grpc.v1.GetHistoryResponse
+	4, // 6: grpc.v1.Translation.GetLanguages:output_type -> grpc.v1.GetLanguagesResponse
+	7, // 7: grpc.v1.Translation.DetectLanguage:output_type -> grpc.v1.DetectLanguageResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_docs_proto_v1_translation_history_proto_init() }
@@ -229,7 +523,7 @@ func file_docs_proto_v1_translation_history_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_docs_proto_v1_translation_history_proto_rawDesc), len(file_docs_proto_v1_translation_history_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
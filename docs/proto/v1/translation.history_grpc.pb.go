@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Translation_GetHistory_FullMethodName = "/grpc.v1.Translation/GetHistory"
+	Translation_GetHistory_FullMethodName     = "/grpc.v1.Translation/GetHistory"
+	Translation_GetLanguages_FullMethodName   = "/grpc.v1.Translation/GetLanguages"
+	Translation_DetectLanguage_FullMethodName = "/grpc.v1.Translation/DetectLanguage"
 )
 
 // TranslationClient is the client API for Translation service.
@@ -30,6 +32,10 @@ const (
 type TranslationClient interface {
 	// RPC method to get translation history.
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// RPC method to get the languages of the translation provider.
+	GetLanguages(ctx context.Context, in *GetLanguagesRequest, opts ...grpc.CallOption) (*GetLanguagesResponse, error)
+	// RPC method to detect the language of a text.
+	DetectLanguage(ctx context.Context, in *DetectLanguageRequest, opts ...grpc.CallOption) (*DetectLanguageResponse, error)
 }
 
 type translationClient struct {
@@ -50,6 +56,26 @@ func (c *translationClient) GetHistory(ctx context.Context, in *GetHistoryReques
 	return out, nil
 }
 
+func (c *translationClient) GetLanguages(ctx context.Context, in *GetLanguagesRequest, opts ...grpc.CallOption) (*GetLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLanguagesResponse)
+	err := c.cc.Invoke(ctx, Translation_GetLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationClient) DetectLanguage(ctx context.Context, in *DetectLanguageRequest, opts ...grpc.CallOption) (*DetectLanguageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectLanguageResponse)
+	err := c.cc.Invoke(ctx, Translation_DetectLanguage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TranslationServer is the server API for Translation service.
 // All implementations must embed UnimplementedTranslationServer
 // for forward compatibility.
@@ -58,6 +84,10 @@ func (c *translationClient) GetHistory(ctx context.Context, in *GetHistoryReques
 type TranslationServer interface {
 	// RPC method to get translation history.
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// RPC method to get the languages of the translation provider.
+	GetLanguages(context.Context, *GetLanguagesRequest) (*GetLanguagesResponse, error)
+	// RPC method to detect the language of a text.
+	DetectLanguage(context.Context, *DetectLanguageRequest) (*DetectLanguageResponse, error)
 	mustEmbedUnimplementedTranslationServer()
 }
 
@@ -71,6 +101,12 @@ type UnimplementedTranslationServer struct{}
 func (UnimplementedTranslationServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
 }
+func (UnimplementedTranslationServer) GetLanguages(context.Context, *GetLanguagesRequest) (*GetLanguagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLanguages not implemented")
+}
+func (UnimplementedTranslationServer) DetectLanguage(context.Context, *DetectLanguageRequest) (*DetectLanguageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetectLanguage not implemented")
+}
 func (UnimplementedTranslationServer) mustEmbedUnimplementedTranslationServer() {}
 func (UnimplementedTranslationServer) testEmbeddedByValue()                     {}
 
@@ -110,6 +146,42 @@ func _Translation_GetHistory_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Translation_GetLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServer).GetLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Translation_GetLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServer).GetLanguages(ctx, req.(*GetLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Translation_DetectLanguage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectLanguageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServer).DetectLanguage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Translation_DetectLanguage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServer).DetectLanguage(ctx, req.(*DetectLanguageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Translation_ServiceDesc is the grpc.ServiceDesc for Translation service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +193,14 @@ var Translation_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _Translation_GetHistory_Handler,
 		},
+		{
+			MethodName: "GetLanguages",
+			Handler:    _Translation_GetLanguages_Handler,
+		},
+		{
+			MethodName: "DetectLanguage",
+			Handler:    _Translation_DetectLanguage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "docs/proto/v1/translation.history.proto",
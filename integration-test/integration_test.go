@@ -113,10 +113,12 @@ func TestMain(m *testing.M) {
 
 // HTTP POST: /v1/translation/do-translate.
 func TestHTTPDoTranslateV1(t *testing.T) {
+	// The app runs with the offline provider and integration-test/testdata/dictionary.json.
 	tests := []struct {
 		description string
 		body        string
 		expected    int
+		translation string
 	}{
 		{
 			description: "DoTranslate Success",
@@ -125,7 +127,8 @@ func TestHTTPDoTranslateV1(t *testing.T) {
 				"original": "текст для перевода",
 				"source": "auto"
 			}`,
-			expected: http.StatusOK,
+			expected:    http.StatusOK,
+			translation: "text for translation",
 		},
 		{
 			description: "DoTranslate Success",
@@ -134,7 +137,18 @@ func TestHTTPDoTranslateV1(t *testing.T) {
 				"original": "Текст для перевода",
 				"source": "ru"
 			}`,
-			expected: http.StatusOK,
+			expected:    http.StatusOK,
+			translation: "Text for translation",
+		},
+		{
+			description: "DoTranslate Pseudo-localization",
+			body: `{
+				"destination": "de",
+				"original": "text",
+				"source": "en"
+			}`,
+			expected:    http.StatusOK,
+			translation: "[!! ŧęxŧ !!]",
 		},
 		{
 			description: "DoTranslate Fail",
@@ -163,6 +177,22 @@ func TestHTTPDoTranslateV1(t *testing.T) {
 			if resp.StatusCode != tt.expected {
 				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
 			}
+
+			if tt.translation == "" {
+				return
+			}
+
+			var body struct {
+				Translation string `json:"translation"`
+			}
+
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+
+			if body.Translation != tt.translation {
+				t.Errorf("Expected translation %q, got %q", tt.translation, body.Translation)
+			}
 		})
 	}
 }
@@ -237,7 +267,11 @@ func TestClientGRPCV1(t *testing.T) {
 
 // RabbitMQ RPC Client V1: getHistory.
 func TestClientRMQRPCV1(t *testing.T) { //nolint: dupl,gocritic,nolintlint
-	client, err := rmqClient.New(rmqURL, rpcServerExchange, rpcClientExchange)
+	client, err := rmqClient.New(rmqURL, rpcServerExchange, rpcClientExchange,
+		rmqClient.DirectReplyTo(true),
+		rmqClient.PublisherConfirms(time.Second),
+		rmqClient.Mandatory(true),
+	)
 	if err != nil {
 		t.Fatal("RabbitMQ RPC Client - init error - rmqClient.New", err)
 	}
@@ -263,7 +297,7 @@ func TestClientRMQRPCV1(t *testing.T) { //nolint: dupl,gocritic,nolintlint
 	for i := 0; i < requests; i++ {
 		var history historyResponse
 
-		err = client.RemoteCall("v1.getHistory", nil, &history)
+		err = client.RemoteCall(context.Background(), "v1.getHistory", nil, &history)
 		if err != nil {
 			t.Fatal("RabbitMQ RPC Client - remote call error - client.RemoteCall", err)
 		}
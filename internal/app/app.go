@@ -10,21 +10,30 @@ import (
 	"time"
 
 	"github.com/evrone/go-clean-template/config"
-	amqprpc "github.com/evrone/go-clean-template/internal/controller/amqp_rpc"
 	"github.com/evrone/go-clean-template/internal/controller/grpc"
-	natsrpc "github.com/evrone/go-clean-template/internal/controller/nats_rpc"
+	grpcmiddleware "github.com/evrone/go-clean-template/internal/controller/grpc/middleware"
+	natsjs "github.com/evrone/go-clean-template/internal/controller/nats_js"
 	"github.com/evrone/go-clean-template/internal/controller/restapi"
+	"github.com/evrone/go-clean-template/internal/controller/rpc"
 	"github.com/evrone/go-clean-template/internal/repo/persistent"
 	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/evrone/go-clean-template/internal/repo/webapi/resilient"
+	"github.com/evrone/go-clean-template/internal/usecase/glossary"
 	"github.com/evrone/go-clean-template/internal/usecase/translation"
+	"github.com/evrone/go-clean-template/pkg/breaker"
 	"github.com/evrone/go-clean-template/pkg/grpcserver"
 	"github.com/evrone/go-clean-template/pkg/httpserver"
 	"github.com/evrone/go-clean-template/pkg/logger"
+	natsJSConsumer "github.com/evrone/go-clean-template/pkg/nats/nats_js/consumer"
+	natsMicroServer "github.com/evrone/go-clean-template/pkg/nats/nats_micro/server"
 	natsRPCServer "github.com/evrone/go-clean-template/pkg/nats/nats_rpc/server"
 	"github.com/evrone/go-clean-template/pkg/postgres"
 	rmqRPCServer "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc/server"
 	pkgredis "github.com/evrone/go-clean-template/pkg/redis"
+	pkgrpc "github.com/evrone/go-clean-template/pkg/rpc"
 	"github.com/evrone/go-clean-template/pkg/tracer"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const _shutdownTimeout = 5 * time.Second
@@ -62,8 +71,15 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 	}
 
 	// Redis (conditional)
+	var rd *pkgredis.Redis
+
 	if cfg.Redis.Enabled {
-		rd, err := pkgredis.New(cfg.Redis.URL)
+		redisTLS, err := clientTLS(cfg.Redis.TLS)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - redis - clientTLS: %w", err))
+		}
+
+		rd, err = pkgredis.New(cfg.Redis.URL, pkgredis.TLSConfig(redisTLS))
 		if err != nil {
 			l.Fatal(fmt.Errorf("app - Run - redis.New: %w", err))
 		}
@@ -80,25 +96,94 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 	}
 
 	// Repository
-	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+	pgTLS, err := clientTLS(cfg.PG.TLS)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - postgres - clientTLS: %w", err))
+	}
+
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax), postgres.TLSConfig(pgTLS))
 	if err != nil {
 		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
 	}
 	defer pg.Close()
 
+	// Translation provider
+	translationOpts := []resilient.Option{
+		resilient.Attempts(cfg.Translator.RetryAttempts),
+		resilient.Backoff(cfg.Translator.RetryBackoff, cfg.Translator.RetryMaxBackoff),
+		resilient.BreakerOptions(
+			breaker.FailureThreshold(cfg.Translator.BreakerFailureThreshold),
+			breaker.OpenTimeout(cfg.Translator.BreakerOpenTimeout),
+			breaker.HalfOpenProbes(cfg.Translator.BreakerHalfOpenProbes),
+		),
+	}
+
+	if cfg.Metrics.Enabled {
+		translationOpts = append(translationOpts, resilient.Metrics(prometheus.DefaultRegisterer))
+	}
+
+	provider, err := newTranslationProvider(cfg.Translator)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newTranslationProvider: %w", err))
+	}
+
+	translationWebAPI := resilient.New(provider, cfg.Translator.Provider, l, translationOpts...)
+
+	l.Info("app - Run - translation provider: %s", cfg.Translator.Provider)
+
+	glossaryRepo := persistent.NewGlossaryRepo(pg)
+
 	// Use-Case
+	useCaseOpts := []translation.Option{
+		translation.MaxInputLength(cfg.Translator.MaxInputLength),
+		translation.ChunkSize(cfg.Translator.ChunkSize),
+		translation.ChunkConcurrency(cfg.Translator.ChunkConcurrency),
+		translation.FileConcurrency(cfg.Translator.FileConcurrency),
+		translation.Languages(provider.Languages()),
+		translation.DetectionFallback(webapi.NewNGramDetector()),
+		translation.Glossaries(glossaryRepo),
+	}
+
+	if cfg.Metrics.Enabled {
+		useCaseOpts = append(useCaseOpts, translation.Metrics(prometheus.DefaultRegisterer))
+	}
+
+	if cfg.Translator.CoalesceRedis {
+		useCaseOpts = append(useCaseOpts, translation.Coalescer(persistent.NewCoalescer(rd, cfg.Translator.CoalesceLockTTL)))
+	}
+
 	translationUseCase := translation.New(
 		persistent.New(pg),
-		webapi.New(),
+		translationWebAPI,
+		useCaseOpts...,
 	)
 
+	glossaryUseCase := glossary.New(glossaryRepo, glossary.Languages(provider.Languages()))
+
+	// RPC handlers are shared by the RabbitMQ and NATS servers
+	rpcRouter := rpc.NewRouter(cfg, translationUseCase, l)
+
+	// Dependencies reported by /healthz
+	healthChecks := []restapi.HealthCheck{translationWebAPI}
+
 	// RabbitMQ RPC Server (conditional)
 	var rmqServer *rmqRPCServer.Server
 
 	if cfg.RMQ.Enabled {
-		rmqRouter := amqprpc.NewRouter(translationUseCase, l)
+		rmqHealth := newConnectionHealth("rabbitmq")
+		healthChecks = append(healthChecks, rmqHealth)
+
+		rmqTLS, err := clientTLS(cfg.RMQ.TLS)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - rmqServer - clientTLS: %w", err))
+		}
 
-		rmqServer, err = rmqRPCServer.New(cfg.RMQ.URL, cfg.RMQ.ServerExchange, rmqRouter, l)
+		rmqServer, err = rmqRPCServer.New(cfg.RMQ.URL, cfg.RMQ.ServerExchange, rpcRouter, l,
+			rmqRPCServer.MaxPriority(cfg.RMQ.MaxPriority),
+			rmqRPCServer.TLS(rmqTLS),
+			rmqRPCServer.ExternalAuth(cfg.RMQ.ExternalAuth),
+			rmqRPCServer.OnStateChange(rmqHealth.setState),
+		)
 		if err != nil {
 			l.Fatal(fmt.Errorf("app - Run - rmqServer - server.New: %w", err))
 		}
@@ -106,13 +191,17 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 		l.Info("app - Run - RabbitMQ RPC server disabled")
 	}
 
+	// TLS and credentials shared by the NATS RPC server and the JetStream consumer
+	natsOpts, err := natsConnOptions(cfg.NATS)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - natsConnOptions: %w", err))
+	}
+
 	// NATS RPC Server (conditional)
-	var natsServer *natsRPCServer.Server
+	var natsServer rpcServer
 
 	if cfg.NATS.Enabled {
-		natsRouter := natsrpc.NewRouter(translationUseCase, l)
-
-		natsServer, err = natsRPCServer.New(cfg.NATS.URL, cfg.NATS.ServerExchange, natsRouter, l)
+		natsServer, err = newNATSServer(cfg, rpcRouter, l, natsOpts)
 		if err != nil {
 			l.Fatal(fmt.Errorf("app - Run - natsServer - server.New: %w", err))
 		}
@@ -120,19 +209,68 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 		l.Info("app - Run - NATS RPC server disabled")
 	}
 
+	// NATS JetStream Consumer (conditional)
+	var natsConsumer *natsJSConsumer.Consumer
+
+	if cfg.NATSJS.Enabled {
+		natsJSRouter := natsjs.NewRouter(translationUseCase, l)
+
+		natsConsumer, err = natsJSConsumer.New(cfg.NATS.URL, cfg.NATSJS.Stream, cfg.NATSJS.Subject, natsJSRouter, l,
+			natsJSConsumer.Durable(cfg.NATSJS.Durable),
+			natsJSConsumer.MaxDeliver(cfg.NATSJS.MaxDeliver),
+			natsJSConsumer.Backoff(cfg.NATSJS.Backoff, cfg.NATSJS.MaxBackoff),
+			natsJSConsumer.ResultStream(cfg.NATSJS.ResultStream, cfg.NATSJS.ResultSubject),
+			natsJSConsumer.DeadLetterStream(cfg.NATSJS.DeadLetterStream, cfg.NATSJS.DeadLetterSubject),
+			natsJSConsumer.ResultRetention(natsJSConsumer.Retention{
+				MaxAge:   cfg.NATSJS.ResultMaxAge,
+				MaxMsgs:  cfg.NATSJS.ResultMaxMsgs,
+				MaxBytes: cfg.NATSJS.ResultMaxBytes,
+			}),
+			natsJSConsumer.DeadLetterRetention(natsJSConsumer.Retention{
+				MaxAge:   cfg.NATSJS.DeadLetterMaxAge,
+				MaxMsgs:  cfg.NATSJS.DeadLetterMaxMsgs,
+				MaxBytes: cfg.NATSJS.DeadLetterMaxBytes,
+			}),
+			natsJSConsumer.ConnOptions(natsOpts...),
+		)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - natsConsumer - consumer.New: %w", err))
+		}
+	} else {
+		l.Info("app - Run - NATS JetStream consumer disabled")
+	}
+
 	// gRPC Server (conditional)
 	var grpcServer *grpcserver.Server
 
 	if cfg.GRPC.Enabled {
-		grpcServer = grpcserver.New(l, grpcserver.Port(cfg.GRPC.Port))
+		grpcTLS, err := serverTLS(cfg.GRPC.TLS, l)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - grpcServer - serverTLS: %w", err))
+		}
+
+		grpcServer = grpcserver.New(l,
+			grpcserver.Port(cfg.GRPC.Port),
+			grpcserver.TLS(grpcTLS),
+			grpcserver.UnaryInterceptors(grpcmiddleware.Logger(l)),
+		)
 		grpc.NewRouter(grpcServer.App, translationUseCase, l)
 	} else {
 		l.Info("app - Run - gRPC server disabled")
 	}
 
 	// HTTP Server (always enabled)
-	httpServer := httpserver.New(l, httpserver.Port(cfg.HTTP.Port), httpserver.Prefork(cfg.HTTP.UsePreforkMode))
-	restapi.NewRouter(httpServer.App, cfg, translationUseCase, l)
+	httpTLS, err := serverTLS(cfg.HTTP.TLS, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - httpServer - serverTLS: %w", err))
+	}
+
+	httpServer := httpserver.New(l,
+		httpserver.Port(cfg.HTTP.Port),
+		httpserver.Prefork(cfg.HTTP.UsePreforkMode),
+		httpserver.TLS(httpTLS),
+	)
+	restapi.NewRouter(httpServer.App, cfg, translationUseCase, glossaryUseCase, l, healthChecks...)
 
 	// Start servers
 	if rmqServer != nil {
@@ -143,6 +281,10 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 		natsServer.Start()
 	}
 
+	if natsConsumer != nil {
+		natsConsumer.Start()
+	}
+
 	if grpcServer != nil {
 		grpcServer.Start()
 	}
@@ -164,6 +306,8 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 		l.Error(fmt.Errorf("app - Run - rmqServer.Notify: %w", err))
 	case err = <-notifyIfEnabled(natsServer):
 		l.Error(fmt.Errorf("app - Run - natsServer.Notify: %w", err))
+	case err = <-notifyIfEnabled(natsConsumer):
+		l.Error(fmt.Errorf("app - Run - natsConsumer.Notify: %w", err))
 	}
 
 	// Shutdown
@@ -192,6 +336,13 @@ func Run(cfg *config.Config) { //nolint: gocyclo,cyclop,funlen,gocritic,nolintli
 			l.Error(fmt.Errorf("app - Run - natsServer.Shutdown: %w", err))
 		}
 	}
+
+	if natsConsumer != nil {
+		err = natsConsumer.Shutdown()
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - natsConsumer.Shutdown: %w", err))
+		}
+	}
 }
 
 // notifiable is an interface for servers that can notify errors.
@@ -199,6 +350,34 @@ type notifiable interface {
 	Notify() <-chan error
 }
 
+// rpcServer is an interface for RPC servers with interchangeable implementations.
+type rpcServer interface {
+	notifiable
+	Start()
+	Shutdown() error
+}
+
+// newNATSServer creates the NATS RPC server, either plain or based on the NATS micro framework.
+func newNATSServer(cfg *config.Config, router *pkgrpc.Router, l logger.Interface, connOpts []nats.Option) (rpcServer, error) {
+	if cfg.NATS.Micro {
+		return natsMicroServer.New(cfg.NATS.URL, cfg.NATS.ServerExchange, router, l,
+			natsMicroServer.Name(cfg.App.Name),
+			natsMicroServer.Version(cfg.App.Version),
+			natsMicroServer.Metadata(map[string]string{"env": cfg.App.Env}),
+			natsMicroServer.QueueGroup(cfg.NATS.QueueGroup),
+			natsMicroServer.DrainTimeout(cfg.NATS.DrainTimeout),
+			natsMicroServer.ConnOptions(connOpts...),
+		)
+	}
+
+	return natsRPCServer.New(cfg.NATS.URL, cfg.NATS.ServerExchange, router, l,
+		natsRPCServer.QueueGroup(cfg.NATS.QueueGroup),
+		natsRPCServer.SubjectPerHandler(cfg.NATS.SubjectPerHandler),
+		natsRPCServer.DrainTimeout(cfg.NATS.DrainTimeout),
+		natsRPCServer.ConnOptions(connOpts...),
+	)
+}
+
 // notifyIfEnabled returns the notify channel if the server is not nil,
 // otherwise returns a channel that never receives.
 func notifyIfEnabled[T notifiable](server T) <-chan error {
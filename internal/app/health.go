@@ -0,0 +1,36 @@
+package app
+
+import (
+	"sync/atomic"
+
+	rmqrpc "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc"
+)
+
+// connectionHealth reports the state of a RabbitMQ connection in /healthz, fed by its state change callback.
+type connectionHealth struct {
+	name  string
+	state atomic.Int64
+}
+
+func newConnectionHealth(name string) *connectionHealth {
+	h := &connectionHealth{name: name}
+	h.state.Store(int64(rmqrpc.StateReconnecting))
+
+	return h
+}
+
+// Name -.
+func (h *connectionHealth) Name() string {
+	return h.name
+}
+
+// Status returns the connection state and false unless connected.
+func (h *connectionHealth) Status() (string, bool) {
+	state := rmqrpc.State(h.state.Load())
+
+	return state.String(), state == rmqrpc.StateConnected
+}
+
+func (h *connectionHealth) setState(state rmqrpc.State) {
+	h.state.Store(int64(state))
+}
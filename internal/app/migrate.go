@@ -5,6 +5,7 @@ package app
 import (
 	"errors"
 	"log"
+	"net/url"
 	"os"
 	"time"
 
@@ -25,7 +26,7 @@ func init() {
 		log.Fatalf("migrate: environment variable not declared: PG_URL")
 	}
 
-	databaseURL += "?sslmode=disable"
+	databaseURL += "?" + sslParams().Encode()
 
 	var (
 		attempts = _defaultAttempts
@@ -61,3 +62,30 @@ func init() {
 
 	log.Printf("Migrate: up success")
 }
+
+// sslParams maps PG_TLS_* variables to libpq URL parameters.
+func sslParams() url.Values {
+	params := url.Values{"sslmode": {"disable"}}
+
+	if os.Getenv("PG_TLS_ENABLED") != "true" {
+		return params
+	}
+
+	params.Set("sslmode", "verify-full")
+
+	if os.Getenv("PG_TLS_INSECURE_SKIP_VERIFY") == "true" {
+		params.Set("sslmode", "require")
+	}
+
+	for param, env := range map[string]string{
+		"sslrootcert": "PG_TLS_CA_FILE",
+		"sslcert":     "PG_TLS_CERT_FILE",
+		"sslkey":      "PG_TLS_KEY_FILE",
+	} {
+		if value := os.Getenv(env); value != "" {
+			params.Set(param, value)
+		}
+	}
+
+	return params
+}
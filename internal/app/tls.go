@@ -0,0 +1,86 @@
+package app
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/evrone/go-clean-template/config"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/nats-io/nats.go"
+)
+
+// clientTLS returns nil if TLS is disabled for the connection.
+func clientTLS(cfg config.TLS) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // TLS disabled
+	}
+
+	tlsConfig, err := tlsconfig.Client(
+		tlsconfig.CAFile(cfg.CAFile),
+		tlsconfig.Certificate(cfg.CertFile, cfg.KeyFile),
+		tlsconfig.ServerName(cfg.ServerName),
+		tlsconfig.InsecureSkipVerify(cfg.InsecureSkipVerify),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig.Client: %w", err)
+	}
+
+	return tlsConfig, nil
+}
+
+// serverTLS returns nil if TLS is disabled for the server.
+func serverTLS(cfg config.ServerTLS, l logger.Interface) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // TLS disabled
+	}
+
+	tlsConfig, err := tlsconfig.Server(
+		tlsconfig.Certificate(cfg.CertFile, cfg.KeyFile),
+		tlsconfig.CAFile(cfg.ClientCAFile),
+		tlsconfig.ReloadInterval(cfg.ReloadInterval),
+		tlsconfig.OnReload(func(err error) {
+			if err != nil {
+				l.Error(fmt.Errorf("app - serverTLS - reload %s: %w", cfg.CertFile, err))
+
+				return
+			}
+
+			l.Info("app - serverTLS - reloaded %s", cfg.CertFile)
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig.Server: %w", err)
+	}
+
+	return tlsConfig, nil
+}
+
+// natsConnOptions returns the TLS and authentication options shared by all NATS connections.
+func natsConnOptions(cfg config.NATS) ([]nats.Option, error) {
+	var opts []nats.Option
+
+	tlsConfig, err := clientTLS(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	if cfg.CredsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredsFile))
+	}
+
+	if cfg.NKeyFile != "" {
+		nkey, err := nats.NkeyOptionFromSeed(cfg.NKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("nats.NkeyOptionFromSeed: %w", err)
+		}
+
+		opts = append(opts, nkey)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/evrone/go-clean-template/config"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+)
+
+// translationProvider is a provider with its catalogue of languages.
+type translationProvider interface {
+	repo.TranslationWebAPI
+	Languages() []entity.Language
+}
+
+// newTranslationProvider returns the provider selected by TRANSLATOR_PROVIDER.
+func newTranslationProvider(cfg config.Translator) (translationProvider, error) {
+	if cfg.Provider == "offline" {
+		offline, err := webapi.NewOffline(cfg.OfflineDictionary)
+		if err != nil {
+			return nil, fmt.Errorf("webapi.NewOffline: %w", err)
+		}
+
+		return offline, nil
+	}
+
+	return webapi.New(webapi.Timeout(cfg.Timeout)), nil
+}
@@ -0,0 +1,50 @@
+// Package middleware implements gRPC server interceptors.
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func buildRequestMessage(ctx context.Context, method string, err error, latency time.Duration) string {
+	var result strings.Builder
+
+	if p, ok := peer.FromContext(ctx); ok {
+		result.WriteString(p.Addr.String())
+	}
+
+	// Verified client certificate (mTLS).
+	if subject := tlsconfig.ClientSubject(ctx); subject != "" {
+		result.WriteString(" ")
+		result.WriteString(subject)
+	}
+
+	result.WriteString(" - ")
+	result.WriteString(method)
+	result.WriteString(" - ")
+	result.WriteString(status.Code(err).String())
+	result.WriteString(" - ")
+	result.WriteString(latency.String())
+
+	return result.String()
+}
+
+// Logger returns an interceptor that logs the peer, client certificate subject, method, status code and latency.
+func Logger(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		l.Info(buildRequestMessage(ctx, info.FullMethod, err, time.Since(start)))
+
+		return resp, err
+	}
+}
@@ -0,0 +1,22 @@
+package response
+
+import (
+	v1 "github.com/evrone/go-clean-template/docs/proto/v1"
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// NewLanguages -.
+func NewLanguages(languageList entity.LanguageList) *v1.GetLanguagesResponse {
+	languages := make([]*v1.Language, len(languageList.Languages))
+
+	for i, l := range languageList.Languages {
+		languages[i] = &v1.Language{
+			Code:        l.Code,
+			Name:        l.Name,
+			Source:      l.Source,
+			Destination: l.Destination,
+		}
+	}
+
+	return &v1.GetLanguagesResponse{Languages: languages}
+}
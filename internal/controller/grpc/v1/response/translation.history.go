@@ -11,10 +11,12 @@ func NewTranslationHistory(translationHistory entity.TranslationHistory) *v1.Get
 
 	for i, h := range translationHistory.History {
 		history[i] = &v1.TranslationHistory{
-			Source:      h.Source,
-			Destination: h.Destination,
-			Original:    h.Original,
-			Translation: h.Translation,
+			Source:              h.Source,
+			Destination:         h.Destination,
+			Original:            h.Original,
+			Translation:         h.Translation,
+			DetectedLanguage:    h.DetectedLanguage,
+			DetectionConfidence: h.DetectionConfidence,
 		}
 	}
 
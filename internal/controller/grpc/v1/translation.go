@@ -18,3 +18,25 @@ func (r *V1) GetHistory(ctx context.Context, _ *v1.GetHistoryRequest) (*v1.GetHi
 
 	return response.NewTranslationHistory(translationHistory), nil
 }
+
+func (r *V1) GetLanguages(ctx context.Context, _ *v1.GetLanguagesRequest) (*v1.GetLanguagesResponse, error) {
+	languages, err := r.t.Languages(ctx)
+	if err != nil {
+		r.l.Error(err, "grpc - v1 - GetLanguages")
+
+		return nil, fmt.Errorf("grpc - v1 - GetLanguages: %w", err)
+	}
+
+	return response.NewLanguages(languages), nil
+}
+
+func (r *V1) DetectLanguage(ctx context.Context, req *v1.DetectLanguageRequest) (*v1.DetectLanguageResponse, error) {
+	detection, err := r.t.DetectLanguage(ctx, req.GetText())
+	if err != nil {
+		r.l.Error(err, "grpc - v1 - DetectLanguage")
+
+		return nil, fmt.Errorf("grpc - v1 - DetectLanguage: %w", err)
+	}
+
+	return &v1.DetectLanguageResponse{Language: detection.Language, Confidence: detection.Confidence}, nil
+}
@@ -1,15 +1,15 @@
-package v1
+package natsjs
 
 import (
-	v1 "github.com/evrone/go-clean-template/internal/controller/nats_rpc/v1"
+	v1 "github.com/evrone/go-clean-template/internal/controller/nats_js/v1"
 	"github.com/evrone/go-clean-template/internal/usecase"
 	"github.com/evrone/go-clean-template/pkg/logger"
-	"github.com/evrone/go-clean-template/pkg/nats/nats_rpc/server"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_js/consumer"
 )
 
 // NewRouter -.
-func NewRouter(t usecase.Translation, l logger.Interface) map[string]server.CallHandler {
-	routes := make(map[string]server.CallHandler)
+func NewRouter(t usecase.Translation, l logger.Interface) map[string]consumer.Handler {
+	routes := make(map[string]consumer.Handler)
 
 	{
 		v1.NewTranslationRoutes(routes, t, l)
@@ -0,0 +1,9 @@
+package request
+
+// Translate is the payload of a translation request consumed from JetStream.
+type Translate struct {
+	Source      string `json:"source"       validate:"required"`
+	Destination string `json:"destination"  validate:"required"`
+	Original    string `json:"original"     validate:"required"`
+	Format      string `json:"format"       validate:"omitempty,oneof=text html markdown"`
+}
@@ -3,15 +3,15 @@ package v1
 import (
 	"github.com/evrone/go-clean-template/internal/usecase"
 	"github.com/evrone/go-clean-template/pkg/logger"
-	"github.com/evrone/go-clean-template/pkg/nats/nats_rpc/server"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_js/consumer"
 	"github.com/go-playground/validator/v10"
 )
 
 // NewTranslationRoutes -.
-func NewTranslationRoutes(routes map[string]server.CallHandler, t usecase.Translation, l logger.Interface) {
+func NewTranslationRoutes(routes map[string]consumer.Handler, t usecase.Translation, l logger.Interface) {
 	r := &V1{t: t, l: l, v: validator.New(validator.WithRequiredStructEnabled())}
 
 	{
-		routes["v1.getHistory"] = r.getHistory()
+		routes["v1.translate"] = r.translate()
 	}
 }
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evrone/go-clean-template/internal/controller/nats_js/v1/request"
+	"github.com/evrone/go-clean-template/internal/entity"
+	natsjs "github.com/evrone/go-clean-template/pkg/nats/nats_js"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_js/consumer"
+	"github.com/goccy/go-json"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func (r *V1) translate() consumer.Handler {
+	return func(ctx context.Context, msg jetstream.Msg) (interface{}, error) {
+		var body request.Translate
+
+		err := json.Unmarshal(msg.Data(), &body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: nats_js - V1 - translate - json.Unmarshal: %w", natsjs.ErrTerminal, err)
+		}
+
+		err = r.v.Struct(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: nats_js - V1 - translate - r.v.Struct: %w", natsjs.ErrTerminal, err)
+		}
+
+		translation, err := r.t.Translate(ctx, entity.Translation{
+			Source:      body.Source,
+			Destination: body.Destination,
+			Original:    body.Original,
+			Format:      body.Format,
+		})
+		if err != nil {
+			r.l.Error(err, "nats_js - V1 - translate")
+
+			// Client errors will fail the same way on every redelivery.
+			if entity.GetAppError(err).HTTPStatus < http.StatusInternalServerError {
+				return nil, fmt.Errorf("%w: nats_js - V1 - translate: %w", natsjs.ErrTerminal, err)
+			}
+
+			return nil, fmt.Errorf("nats_js - V1 - translate: %w", err)
+		}
+
+		return translation, nil
+	}
+}
@@ -0,0 +1,41 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthCheck reports the state of a dependency in /healthz, e.g. a translation provider circuit breaker.
+type HealthCheck interface {
+	Name() string
+	Status() (state string, healthy bool)
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// health always answers 200 so the probe does not restart the pod while a dependency is down;
+// the status is "degraded" when a check is unhealthy.
+func health(checks []HealthCheck) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		resp := healthResponse{Status: "ok"}
+
+		if len(checks) > 0 {
+			resp.Checks = make(map[string]string, len(checks))
+		}
+
+		for _, check := range checks {
+			state, healthy := check.Status()
+			resp.Checks[check.Name()] = state
+
+			if !healthy {
+				resp.Status = "degraded"
+			}
+		}
+
+		return ctx.Status(http.StatusOK).JSON(resp)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -20,6 +21,13 @@ func buildRequestMessage(ctx *fiber.Ctx, latency time.Duration) string {
 	}
 
 	result.WriteString(ctx.IP())
+
+	// Verified client certificate (mTLS).
+	if subject := tlsconfig.ClientSubject(ctx.UserContext()); subject != "" {
+		result.WriteString(" ")
+		result.WriteString(subject)
+	}
+
 	result.WriteString(" - ")
 	result.WriteString(ctx.Method())
 	result.WriteString(" ")
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Timeout bounds the request context, so calls made by the handler stop once the response is no longer useful.
+// fasthttp does not cancel the context when the client disconnects; the deadline is the only bound. Zero disables it.
+func Timeout(timeout time.Duration) func(c *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if timeout <= 0 {
+			return ctx.Next()
+		}
+
+		userCtx, cancel := context.WithTimeout(ctx.UserContext(), timeout)
+		defer cancel()
+
+		ctx.SetUserContext(userCtx)
+
+		return ctx.Next()
+	}
+}
@@ -2,8 +2,6 @@
 package restapi
 
 import (
-	"net/http"
-
 	"github.com/ansrivas/fiberprometheus/v2"
 	"github.com/evrone/go-clean-template/config"
 	_ "github.com/evrone/go-clean-template/docs" // Swagger docs.
@@ -22,8 +20,15 @@ import (
 // @version     1.0
 // @host        localhost:8080
 // @BasePath    /v1
-func NewRouter(app *fiber.App, cfg *config.Config, t usecase.Translation, l logger.Interface) {
-	// Middleware — order matters: RequestID → Security → CORS → RateLimit → Logger → Recovery.
+func NewRouter(
+	app *fiber.App,
+	cfg *config.Config,
+	t usecase.Translation,
+	g usecase.Glossary,
+	l logger.Interface,
+	checks ...HealthCheck,
+) {
+	// Middleware — order matters: RequestID → Security → CORS → RateLimit → Logger → Recovery → Timeout.
 	app.Use(middleware.RequestID())
 	app.Use(middleware.Security())
 	app.Use(middleware.CORS(middleware.CORSConfig{
@@ -37,6 +42,7 @@ func NewRouter(app *fiber.App, cfg *config.Config, t usecase.Translation, l logg
 	}))
 	app.Use(middleware.Logger(l))
 	app.Use(middleware.Recovery(l))
+	app.Use(middleware.Timeout(cfg.HTTP.RequestTimeout))
 
 	// OpenTelemetry tracing (conditional).
 	if cfg.Tracer.Enabled {
@@ -56,11 +62,12 @@ func NewRouter(app *fiber.App, cfg *config.Config, t usecase.Translation, l logg
 	}
 
 	// K8s probe.
-	app.Get("/healthz", func(ctx *fiber.Ctx) error { return ctx.SendStatus(http.StatusOK) })
+	app.Get("/healthz", health(checks))
 
 	// Routers.
 	apiV1Group := app.Group("/v1")
 	{
 		v1.NewTranslationRoutes(apiV1Group, t, l)
+		v1.NewGlossaryRoutes(apiV1Group, g, l)
 	}
 }
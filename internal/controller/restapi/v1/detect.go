@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/evrone/go-clean-template/internal/controller/restapi/v1/request"
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary     Detect language
+// @Description Detect the language of a text
+// @ID          detect-language
+// @Tags  	    translation
+// @Accept      json
+// @Produce     json
+// @Param       request body request.DetectLanguage true "Text to detect the language of"
+// @Success     200 {object} entity.LanguageDetection
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /translation/detect [post]
+func (r *V1) detectLanguage(ctx *fiber.Ctx) error {
+	var body request.DetectLanguage
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - detectLanguage")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - detectLanguage")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	detection, err := r.t.DetectLanguage(ctx.UserContext(), body.Text)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - detectLanguage")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(detection)
+}
@@ -16,5 +16,12 @@ func appErrorResponse(ctx *fiber.Ctx, err error) error {
 	appErr := entity.GetAppError(err)
 	requestID, _ := ctx.Locals("request_id").(string)
 
-	return ctx.Status(appErr.HTTPStatus).JSON(response.NewErrorResponse(requestID, appErr.Code, appErr.Message))
+	if retryAfter, ok := appErr.Details[entity.DetailRetryAfter]; ok {
+		ctx.Set(fiber.HeaderRetryAfter, retryAfter)
+	}
+
+	resp := response.NewErrorResponse(requestID, appErr.Code, appErr.Message)
+	resp.Details = appErr.Details
+
+	return ctx.Status(appErr.HTTPStatus).JSON(resp)
 }
@@ -0,0 +1,354 @@
+package v1
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/evrone/go-clean-template/internal/controller/restapi/v1/request"
+	"github.com/evrone/go-clean-template/internal/controller/restapi/v1/response"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// _glossaryFormats maps file extensions to glossary import formats.
+//
+//nolint:gochecknoglobals // lookup table
+var _glossaryFormats = map[string]string{
+	".csv": entity.GlossaryFormatCSV,
+	".tbx": entity.GlossaryFormatTBX,
+	".xml": entity.GlossaryFormatTBX,
+}
+
+// @Summary     Show glossaries
+// @Description Show the glossaries, without their entries
+// @ID          glossaries
+// @Tags  	    glossary
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.GlossaryList
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries [get]
+func (r *V1) glossaries(ctx *fiber.Ctx) error {
+	glossaries, err := r.g.Glossaries(ctx.UserContext())
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - glossaries")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(glossaries)
+}
+
+// @Summary     Show a glossary
+// @Description Show a glossary with its entries
+// @ID          glossary
+// @Tags  	    glossary
+// @Accept      json
+// @Produce     json
+// @Param       id  path     int true "Glossary id"
+// @Success     200 {object} entity.Glossary
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id} [get]
+func (r *V1) glossary(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	glossary, err := r.g.Glossary(ctx.UserContext(), id)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - glossary")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(glossary)
+}
+
+// @Summary     Create a glossary
+// @Description Create a glossary of a language pair, with its entries
+// @ID          create-glossary
+// @Tags  	    glossary
+// @Accept      json
+// @Produce     json
+// @Param       request body request.Glossary true "Glossary"
+// @Success     201 {object} entity.Glossary
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries [post]
+func (r *V1) createGlossary(ctx *fiber.Ctx) error {
+	var body request.Glossary
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - createGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - createGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	glossary, err := r.g.CreateGlossary(ctx.UserContext(), entity.Glossary{
+		Name:        body.Name,
+		Source:      body.Source,
+		Destination: body.Destination,
+		Entries:     glossaryEntries(body.Entries),
+	})
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - createGlossary")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(glossary)
+}
+
+// @Summary     Update a glossary
+// @Description Rename a glossary or change its languages, keeping its entries
+// @ID          update-glossary
+// @Tags  	    glossary
+// @Accept      json
+// @Produce     json
+// @Param       id      path int              true "Glossary id"
+// @Param       request body request.Glossary true "Glossary, entries are ignored"
+// @Success     200 {object} entity.Glossary
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id} [put]
+func (r *V1) updateGlossary(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	var body request.Glossary
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - updateGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - updateGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	glossary, err := r.g.UpdateGlossary(ctx.UserContext(), entity.Glossary{
+		ID:          id,
+		Name:        body.Name,
+		Source:      body.Source,
+		Destination: body.Destination,
+	})
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - updateGlossary")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(glossary)
+}
+
+// @Summary     Delete a glossary
+// @Description Delete a glossary and its entries
+// @ID          delete-glossary
+// @Tags  	    glossary
+// @Param       id path int true "Glossary id"
+// @Success     204
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id} [delete]
+func (r *V1) deleteGlossary(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	err := r.g.DeleteGlossary(ctx.UserContext(), id)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - deleteGlossary")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+// @Summary     Add glossary entries
+// @Description Add entries to a glossary; an entry replaces the entry with the same term
+// @ID          store-glossary-entries
+// @Tags  	    glossary
+// @Accept      json
+// @Produce     json
+// @Param       id      path int                     true "Glossary id"
+// @Param       request body request.GlossaryEntries true "Entries"
+// @Success     200 {object} response.GlossaryEntries
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id}/entries [post]
+func (r *V1) storeGlossaryEntries(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	var body request.GlossaryEntries
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - storeGlossaryEntries")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - storeGlossaryEntries")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	entries, err := r.g.StoreEntries(ctx.UserContext(), id, glossaryEntries(body.Entries))
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - storeGlossaryEntries")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(response.GlossaryEntries{Entries: entries})
+}
+
+// @Summary     Delete a glossary entry
+// @Description Delete an entry of a glossary
+// @ID          delete-glossary-entry
+// @Tags  	    glossary
+// @Param       id      path int true "Glossary id"
+// @Param       entryId path int true "Entry id"
+// @Success     204
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id}/entries/{entryId} [delete]
+func (r *V1) deleteGlossaryEntry(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	entryID, ok := paramID(ctx, "entryId")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid entry id")
+	}
+
+	err := r.g.DeleteEntry(ctx.UserContext(), id, entryID)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - deleteGlossaryEntry")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+// @Summary     Import glossary entries
+// @Description Add the terms of a CSV or TBX file to a glossary, in the languages of the glossary.
+// @Description CSV records are a term and its translation, after an optional header naming the columns
+// @Description term (or the source language), translation (or the destination language), do_not_translate and case_sensitive
+// @ID          import-glossary
+// @Tags  	    glossary
+// @Accept      mpfd
+// @Produce     json
+// @Param       id     path     int    true  "Glossary id"
+// @Param       file   formData file   true  "CSV or TBX file"
+// @Param       format formData string false "csv or tbx; detected from the file extension by default"
+// @Success     200 {object} entity.Glossary
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /glossaries/{id}/import [post]
+func (r *V1) importGlossary(ctx *fiber.Ctx) error {
+	id, ok := paramID(ctx, "id")
+	if !ok {
+		return errorResponse(ctx, http.StatusBadRequest, "invalid glossary id")
+	}
+
+	var body request.ImportGlossary
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - importGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - importGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - importGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "file is required")
+	}
+
+	format := body.Format
+	if format == "" {
+		format = _glossaryFormats[strings.ToLower(filepath.Ext(header.Filename))]
+	}
+
+	if format == "" {
+		return errorResponse(ctx, http.StatusBadRequest, "unknown file format, set format")
+	}
+
+	content, err := readFile(header)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - importGlossary")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid file")
+	}
+
+	glossary, err := r.g.ImportGlossary(ctx.UserContext(), entity.GlossaryImport{
+		GlossaryID: id,
+		Format:     format,
+		Content:    content,
+	})
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - importGlossary")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(glossary)
+}
+
+func glossaryEntries(entries []request.GlossaryEntry) []entity.GlossaryEntry {
+	converted := make([]entity.GlossaryEntry, len(entries))
+
+	for i, e := range entries {
+		converted[i] = entity.GlossaryEntry{
+			Term:           e.Term,
+			Translation:    e.Translation,
+			DoNotTranslate: e.DoNotTranslate,
+			CaseSensitive:  e.CaseSensitive,
+		}
+	}
+
+	return converted
+}
+
+// paramID returns the positive integer of a path parameter.
+func paramID(ctx *fiber.Ctx, key string) (int64, bool) {
+	id, err := ctx.ParamsInt(key)
+
+	return int64(id), err == nil && id > 0
+}
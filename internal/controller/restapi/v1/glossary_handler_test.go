@@ -0,0 +1,151 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/evrone/go-clean-template/internal/controller/restapi/v1"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/usecase"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupGlossaryRouter(t *testing.T) (*fiber.App, *MockGlossary) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	mockGlossary := NewMockGlossary(mockCtl)
+
+	app := fiber.New()
+	v1.NewGlossaryRoutes(app.Group("/v1"), mockGlossary, logger.New("error"))
+
+	return app, mockGlossary
+}
+
+func jsonRequest(t *testing.T, method, target string, body any) *http.Request {
+	t.Helper()
+
+	content, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, target, bytes.NewReader(content))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+func TestCreateGlossaryHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupGlossaryRouter(t)
+
+	mockUseCase.EXPECT().CreateGlossary(gomock.Any(), entity.Glossary{
+		Name:        "Product",
+		Source:      "en",
+		Destination: "vi",
+		Entries: []entity.GlossaryEntry{
+			{Term: "Acme Cloud", DoNotTranslate: true},
+			{Term: "invoice", Translation: "hóa đơn"},
+		},
+	}).Return(entity.Glossary{ID: 7, Name: "Product", Source: "en", Destination: "vi"}, nil)
+
+	resp, err := app.Test(jsonRequest(t, http.MethodPost, "/v1/glossaries", map[string]any{
+		"name":        "Product",
+		"source":      "en",
+		"destination": "vi",
+		"entries": []map[string]any{
+			{"term": "Acme Cloud", "do_not_translate": true},
+			{"term": "invoice", "translation": "hóa đơn"},
+		},
+	}))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result entity.Glossary
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, int64(7), result.ID)
+}
+
+func TestCreateGlossaryHandler_InvalidBody(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupGlossaryRouter(t)
+
+	resp, err := app.Test(jsonRequest(t, http.MethodPost, "/v1/glossaries", map[string]any{
+		"name":        "Product",
+		"source":      "en",
+		"destination": "vi",
+		"entries":     []map[string]any{{"term": "invoice"}},
+	}))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGlossaryHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupGlossaryRouter(t)
+
+	mockUseCase.EXPECT().Glossary(gomock.Any(), int64(9)).Return(
+		entity.Glossary{},
+		entity.NewAppError(entity.ErrNotFound, fmt.Errorf("glossary 9: %w", entity.ErrNotFound)),
+	)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/v1/glossaries/9", nil))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/v1/glossaries/first", nil))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDeleteGlossaryEntryHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupGlossaryRouter(t)
+
+	mockUseCase.EXPECT().DeleteEntry(gomock.Any(), int64(7), int64(3)).Return(nil)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/v1/glossaries/7/entries/3", nil))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestImportGlossaryHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupGlossaryRouter(t)
+
+	mockUseCase.EXPECT().ImportGlossary(gomock.Any(), entity.GlossaryImport{
+		GlossaryID: 7,
+		Format:     entity.GlossaryFormatTBX,
+		Content:    []byte("<martif/>"),
+	}).Return(entity.Glossary{ID: 7}, nil)
+
+	resp, err := app.Test(fileRequest(t, "/v1/glossaries/7/import", "terms.xml", "<martif/>", nil))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(fileRequest(t, "/v1/glossaries/7/import", "terms.txt", "invoice", nil))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+var _ usecase.Glossary = (*MockGlossary)(nil)
@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary     Show languages
+// @Description Show the languages of the translation provider and the directions they are translated in
+// @ID          languages
+// @Tags  	    translation
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.LanguageList
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /languages [get]
+func (r *V1) languages(ctx *fiber.Ctx) error {
+	languages, err := r.t.Languages(ctx.UserContext())
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - languages")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(languages)
+}
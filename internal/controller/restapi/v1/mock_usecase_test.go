@@ -41,6 +41,21 @@ func (m *MockTranslation) EXPECT() *MockTranslationMockRecorder {
 	return m.recorder
 }
 
+// DetectLanguage mocks base method.
+func (m *MockTranslation) DetectLanguage(arg0 context.Context, arg1 string) (entity.LanguageDetection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectLanguage", arg0, arg1)
+	ret0, _ := ret[0].(entity.LanguageDetection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectLanguage indicates an expected call of DetectLanguage.
+func (mr *MockTranslationMockRecorder) DetectLanguage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockTranslation)(nil).DetectLanguage), arg0, arg1)
+}
+
 // History mocks base method.
 func (m *MockTranslation) History(arg0 context.Context) (entity.TranslationHistory, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +71,21 @@ func (mr *MockTranslationMockRecorder) History(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockTranslation)(nil).History), arg0)
 }
 
+// Languages mocks base method.
+func (m *MockTranslation) Languages(arg0 context.Context) (entity.LanguageList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Languages", arg0)
+	ret0, _ := ret[0].(entity.LanguageList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Languages indicates an expected call of Languages.
+func (mr *MockTranslationMockRecorder) Languages(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Languages", reflect.TypeOf((*MockTranslation)(nil).Languages), arg0)
+}
+
 // Translate mocks base method.
 func (m *MockTranslation) Translate(arg0 context.Context, arg1 entity.Translation) (entity.Translation, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +100,160 @@ func (mr *MockTranslationMockRecorder) Translate(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslation)(nil).Translate), arg0, arg1)
 }
+
+// TranslateFile mocks base method.
+func (m *MockTranslation) TranslateFile(arg0 context.Context, arg1 entity.TranslationFile) (entity.TranslationFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateFile", arg0, arg1)
+	ret0, _ := ret[0].(entity.TranslationFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateFile indicates an expected call of TranslateFile.
+func (mr *MockTranslationMockRecorder) TranslateFile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateFile", reflect.TypeOf((*MockTranslation)(nil).TranslateFile), arg0, arg1)
+}
+
+// MockGlossary is a mock of Glossary interface.
+type MockGlossary struct {
+	ctrl     *gomock.Controller
+	recorder *MockGlossaryMockRecorder
+	isgomock struct{}
+}
+
+// MockGlossaryMockRecorder is the mock recorder for MockGlossary.
+type MockGlossaryMockRecorder struct {
+	mock *MockGlossary
+}
+
+// NewMockGlossary creates a new mock instance.
+func NewMockGlossary(ctrl *gomock.Controller) *MockGlossary {
+	mock := &MockGlossary{ctrl: ctrl}
+	mock.recorder = &MockGlossaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGlossary) EXPECT() *MockGlossaryMockRecorder {
+	return m.recorder
+}
+
+// CreateGlossary mocks base method.
+func (m *MockGlossary) CreateGlossary(arg0 context.Context, arg1 entity.Glossary) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGlossary", arg0, arg1)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGlossary indicates an expected call of CreateGlossary.
+func (mr *MockGlossaryMockRecorder) CreateGlossary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGlossary", reflect.TypeOf((*MockGlossary)(nil).CreateGlossary), arg0, arg1)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockGlossary) DeleteEntry(ctx context.Context, glossaryID, entryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, glossaryID, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockGlossaryMockRecorder) DeleteEntry(ctx, glossaryID, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockGlossary)(nil).DeleteEntry), ctx, glossaryID, entryID)
+}
+
+// DeleteGlossary mocks base method.
+func (m *MockGlossary) DeleteGlossary(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGlossary", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGlossary indicates an expected call of DeleteGlossary.
+func (mr *MockGlossaryMockRecorder) DeleteGlossary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGlossary", reflect.TypeOf((*MockGlossary)(nil).DeleteGlossary), ctx, id)
+}
+
+// Glossaries mocks base method.
+func (m *MockGlossary) Glossaries(arg0 context.Context) (entity.GlossaryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Glossaries", arg0)
+	ret0, _ := ret[0].(entity.GlossaryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Glossaries indicates an expected call of Glossaries.
+func (mr *MockGlossaryMockRecorder) Glossaries(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glossaries", reflect.TypeOf((*MockGlossary)(nil).Glossaries), arg0)
+}
+
+// Glossary mocks base method.
+func (m *MockGlossary) Glossary(ctx context.Context, id int64) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Glossary", ctx, id)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Glossary indicates an expected call of Glossary.
+func (mr *MockGlossaryMockRecorder) Glossary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Glossary", reflect.TypeOf((*MockGlossary)(nil).Glossary), ctx, id)
+}
+
+// ImportGlossary mocks base method.
+func (m *MockGlossary) ImportGlossary(arg0 context.Context, arg1 entity.GlossaryImport) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGlossary", arg0, arg1)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGlossary indicates an expected call of ImportGlossary.
+func (mr *MockGlossaryMockRecorder) ImportGlossary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGlossary", reflect.TypeOf((*MockGlossary)(nil).ImportGlossary), arg0, arg1)
+}
+
+// StoreEntries mocks base method.
+func (m *MockGlossary) StoreEntries(ctx context.Context, glossaryID int64, entries []entity.GlossaryEntry) ([]entity.GlossaryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEntries", ctx, glossaryID, entries)
+	ret0, _ := ret[0].([]entity.GlossaryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreEntries indicates an expected call of StoreEntries.
+func (mr *MockGlossaryMockRecorder) StoreEntries(ctx, glossaryID, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEntries", reflect.TypeOf((*MockGlossary)(nil).StoreEntries), ctx, glossaryID, entries)
+}
+
+// UpdateGlossary mocks base method.
+func (m *MockGlossary) UpdateGlossary(arg0 context.Context, arg1 entity.Glossary) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGlossary", arg0, arg1)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGlossary indicates an expected call of UpdateGlossary.
+func (mr *MockGlossaryMockRecorder) UpdateGlossary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGlossary", reflect.TypeOf((*MockGlossary)(nil).UpdateGlossary), arg0, arg1)
+}
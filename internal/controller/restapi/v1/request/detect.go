@@ -0,0 +1,5 @@
+package request
+
+type DetectLanguage struct {
+	Text string `json:"text" validate:"required" example:"текст для перевода"`
+}
@@ -0,0 +1,27 @@
+package request
+
+// Glossary creates or updates a glossary; entries are only read on creation.
+type Glossary struct {
+	Name        string          `json:"name"         validate:"required"  example:"Product names"`
+	Source      string          `json:"source"       validate:"required"  example:"en"`
+	Destination string          `json:"destination"  validate:"required"  example:"vi"`
+	Entries     []GlossaryEntry `json:"entries"      validate:"omitempty,dive"`
+}
+
+// GlossaryEntry is a term translated as Translation, or kept as it is with DoNotTranslate.
+type GlossaryEntry struct {
+	Term           string `json:"term"              validate:"required"                             example:"sign in"`
+	Translation    string `json:"translation"       validate:"required_unless=DoNotTranslate true"  example:"đăng nhập"`
+	DoNotTranslate bool   `json:"do_not_translate"  example:"false"`
+	CaseSensitive  bool   `json:"case_sensitive"    example:"false"`
+}
+
+// GlossaryEntries adds entries to a glossary.
+type GlossaryEntries struct {
+	Entries []GlossaryEntry `json:"entries" validate:"required,min=1,dive"`
+}
+
+// ImportGlossary is the multipart form of a glossary import, the file is in the "file" part.
+type ImportGlossary struct {
+	Format string `form:"format" validate:"omitempty,oneof=csv tbx" example:"csv"`
+}
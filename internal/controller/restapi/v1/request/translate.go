@@ -4,4 +4,5 @@ type Translate struct {
 	Source      string `json:"source"       validate:"required"  example:"auto"`
 	Destination string `json:"destination"  validate:"required"  example:"en"`
 	Original    string `json:"original"     validate:"required"  example:"текст для перевода"`
+	Format      string `json:"format"       validate:"omitempty,oneof=text html markdown"  example:"text"`
 }
@@ -0,0 +1,8 @@
+package request
+
+// TranslateFile is the multipart form of a resource file translation, the file is in the "file" part.
+type TranslateFile struct {
+	Source      string `form:"source"       validate:"required"  example:"en"`
+	Destination string `form:"destination"  validate:"required"  example:"vi"`
+	Format      string `form:"format"       validate:"omitempty,oneof=json yaml po xliff srt vtt"  example:"json"`
+}
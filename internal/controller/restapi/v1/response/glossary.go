@@ -0,0 +1,8 @@
+package response
+
+import "github.com/evrone/go-clean-template/internal/entity"
+
+// GlossaryEntries is the response of the entries added to a glossary.
+type GlossaryEntries struct {
+	Entries []entity.GlossaryEntry `json:"entries"`
+}
@@ -11,10 +11,32 @@ import (
 func NewTranslationRoutes(apiV1Group fiber.Router, t usecase.Translation, l logger.Interface) {
 	r := &V1{t: t, l: l, v: validator.New(validator.WithRequiredStructEnabled())}
 
+	apiV1Group.Get("/languages", r.languages)
+
 	translationGroup := apiV1Group.Group("/translation")
 
 	{
 		translationGroup.Get("/history", r.history)
 		translationGroup.Post("/do-translate", r.doTranslate)
+		translationGroup.Post("/detect", r.detectLanguage)
+		translationGroup.Post("/files", r.translateFile)
+	}
+}
+
+// NewGlossaryRoutes -.
+func NewGlossaryRoutes(apiV1Group fiber.Router, g usecase.Glossary, l logger.Interface) {
+	r := &V1{g: g, l: l, v: validator.New(validator.WithRequiredStructEnabled())}
+
+	glossaryGroup := apiV1Group.Group("/glossaries")
+
+	{
+		glossaryGroup.Get("", r.glossaries)
+		glossaryGroup.Post("", r.createGlossary)
+		glossaryGroup.Get("/:id", r.glossary)
+		glossaryGroup.Put("/:id", r.updateGlossary)
+		glossaryGroup.Delete("/:id", r.deleteGlossary)
+		glossaryGroup.Post("/:id/entries", r.storeGlossaryEntries)
+		glossaryGroup.Delete("/:id/entries/:entryId", r.deleteGlossaryEntry)
+		glossaryGroup.Post("/:id/import", r.importGlossary)
 	}
 }
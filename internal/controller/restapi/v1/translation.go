@@ -60,6 +60,7 @@ func (r *V1) doTranslate(ctx *fiber.Ctx) error {
 			Source:      body.Source,
 			Destination: body.Destination,
 			Original:    body.Original,
+			Format:      body.Format,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/evrone/go-clean-template/internal/controller/restapi/v1/request"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// _fileFormats maps file extensions to formats and formats to response content types.
+//
+//nolint:gochecknoglobals // lookup tables
+var (
+	_fileFormats = map[string]string{
+		".json":  entity.FileFormatJSON,
+		".yaml":  entity.FileFormatYAML,
+		".yml":   entity.FileFormatYAML,
+		".po":    entity.FileFormatPO,
+		".pot":   entity.FileFormatPO,
+		".xlf":   entity.FileFormatXLIFF,
+		".xliff": entity.FileFormatXLIFF,
+		".srt":   entity.FileFormatSRT,
+		".vtt":   entity.FileFormatVTT,
+	}
+	_fileContentTypes = map[string]string{
+		entity.FileFormatJSON:  fiber.MIMEApplicationJSONCharsetUTF8,
+		entity.FileFormatYAML:  "application/yaml; charset=utf-8",
+		entity.FileFormatPO:    "text/x-gettext-translation; charset=utf-8",
+		entity.FileFormatXLIFF: "application/xliff+xml; charset=utf-8",
+		entity.FileFormatSRT:   "application/x-subrip; charset=utf-8",
+		entity.FileFormatVTT:   "text/vtt; charset=utf-8",
+	}
+)
+
+// @Summary     Translate a file
+// @Description Translate the values of a JSON, YAML, gettext PO or XLIFF 1.2/2.0 resource file, keeping keys, comments and plural forms,
+// @Description or the cues of a SubRip or WebVTT subtitle file, keeping cue identifiers and timings
+// @ID          translate-file
+// @Tags  	    translation
+// @Accept      mpfd
+// @Produce     octet-stream
+// @Param       file        formData file   true  "Resource file"
+// @Param       source      formData string true  "Source language"
+// @Param       destination formData string true  "Destination language"
+// @Param       format      formData string false "json, yaml, po, xliff, srt or vtt; detected from the file extension by default"
+// @Success     200 {file}   file
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     502 {object} response.ErrorResponse
+// @Router      /translation/files [post]
+func (r *V1) translateFile(ctx *fiber.Ctx) error {
+	var body request.TranslateFile
+
+	if err := ctx.BodyParser(&body); err != nil {
+		r.l.Error(err, "restapi - v1 - translateFile")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := r.v.Struct(body); err != nil {
+		r.l.Error(err, "restapi - v1 - translateFile")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid request body")
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - translateFile")
+
+		return errorResponse(ctx, http.StatusBadRequest, "file is required")
+	}
+
+	format := body.Format
+	if format == "" {
+		format = _fileFormats[strings.ToLower(filepath.Ext(header.Filename))]
+	}
+
+	if format == "" {
+		return errorResponse(ctx, http.StatusBadRequest, "unknown file format, set format")
+	}
+
+	content, err := readFile(header)
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - translateFile")
+
+		return errorResponse(ctx, http.StatusBadRequest, "invalid file")
+	}
+
+	file, err := r.t.TranslateFile(ctx.UserContext(), entity.TranslationFile{
+		Name:        header.Filename,
+		Format:      format,
+		Source:      body.Source,
+		Destination: body.Destination,
+		Content:     content,
+	})
+	if err != nil {
+		r.l.Error(err, "restapi - v1 - translateFile")
+
+		return appErrorResponse(ctx, err)
+	}
+
+	// Attachment guesses the content type from the name, set the one of the format after it.
+	ctx.Attachment(file.Name)
+	ctx.Set(fiber.HeaderContentType, _fileContentTypes[file.Format])
+
+	return ctx.Status(http.StatusOK).Send(file.Content)
+}
+
+func readFile(header *multipart.FileHeader) ([]byte, error) {
+	f, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("header.Open: %w", err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	return content, nil
+}
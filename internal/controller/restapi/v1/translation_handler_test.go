@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -84,6 +86,53 @@ func TestHistoryHandler_Error(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
 
+func TestLanguagesHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupRouter(t)
+
+	mockUseCase.EXPECT().Languages(gomock.Any()).Return(entity.LanguageList{
+		Languages: []entity.Language{{Code: "en", Name: "English", Source: true, Destination: true}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/languages", nil)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result entity.LanguageList
+	err = json.NewDecoder(resp.Body).Decode(&result)
+
+	require.NoError(t, err)
+	require.Equal(t, "English", result.Languages[0].Name)
+}
+
+func TestDetectLanguageHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupRouter(t)
+
+	mockUseCase.EXPECT().DetectLanguage(gomock.Any(), "bonjour le monde").
+		Return(entity.LanguageDetection{Language: "fr", Confidence: 0.97}, nil)
+
+	body, _ := json.Marshal(map[string]string{"text": "bonjour le monde"})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/translation/detect", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result entity.LanguageDetection
+	err = json.NewDecoder(resp.Body).Decode(&result)
+
+	require.NoError(t, err)
+	require.Equal(t, entity.LanguageDetection{Language: "fr", Confidence: 0.97}, result)
+}
+
 func TestDoTranslateHandler_Success(t *testing.T) {
 	t.Parallel()
 
@@ -156,6 +205,102 @@ func TestDoTranslateHandler_ValidationError(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
+func TestDoTranslateHandler_Format(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupRouter(t)
+
+	mockUseCase.EXPECT().Translate(gomock.Any(), entity.Translation{
+		Source:      "en",
+		Destination: "vi",
+		Original:    "<b>hello</b>",
+		Format:      entity.FormatHTML,
+	}).Return(entity.Translation{Translation: "<b>xin chào</b>"}, nil)
+
+	for format, status := range map[string]int{"html": http.StatusOK, "docx": http.StatusBadRequest} {
+		body, _ := json.Marshal(map[string]string{
+			"source":      "en",
+			"destination": "vi",
+			"original":    "<b>hello</b>",
+			"format":      format,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/translation/do-translate", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		require.NoError(t, err)
+		require.Equal(t, status, resp.StatusCode, format)
+	}
+}
+
+func TestTranslateFileHandler(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupRouter(t)
+
+	mockUseCase.EXPECT().TranslateFile(gomock.Any(), entity.TranslationFile{
+		Name:        "messages.yml",
+		Format:      entity.FileFormatYAML,
+		Source:      "en",
+		Destination: "vi",
+		Content:     []byte("hello: Hello\n"),
+	}).Return(entity.TranslationFile{
+		Name:    "messages.yml",
+		Format:  entity.FileFormatYAML,
+		Content: []byte("hello: Xin chào\n"),
+	}, nil)
+
+	resp, err := app.Test(fileRequest(t, "/v1/translation/files", "messages.yml", "hello: Hello\n",
+		map[string]string{"source": "en", "destination": "vi"}))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/yaml; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+	require.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "messages.yml")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "hello: Xin chào\n", string(body))
+}
+
+func TestTranslateFileHandler_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	app, _ := setupRouter(t)
+
+	resp, err := app.Test(fileRequest(t, "/v1/translation/files", "messages.txt", "hello",
+		map[string]string{"source": "en", "destination": "vi"}))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func fileRequest(t *testing.T, target, name, content string, fields map[string]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+
+	part, err := writer.CreateFormFile("file", name)
+	require.NoError(t, err)
+
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
 func TestDoTranslateHandler_ServiceError(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +326,32 @@ func TestDoTranslateHandler_ServiceError(t *testing.T) {
 	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
 }
 
+func TestDoTranslateHandler_ProviderUnavailable(t *testing.T) {
+	t.Parallel()
+
+	app, mockUseCase := setupRouter(t)
+
+	mockUseCase.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(
+		entity.Translation{},
+		entity.ErrProviderUnavailable.WithDetails(map[string]string{entity.DetailRetryAfter: "12"}),
+	)
+
+	body, _ := json.Marshal(map[string]string{
+		"source":      "en",
+		"destination": "vi",
+		"original":    "hello",
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/translation/do-translate", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, "12", resp.Header.Get("Retry-After"))
+}
+
 func TestHistoryHandler_RequestIDPresent(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+)
+
+func buildRequestMessage(ctx context.Context, req *rpc.Request, status string, latency time.Duration) string {
+	var result strings.Builder
+
+	// Request ID.
+	if requestID := GetRequestID(ctx); requestID != "" {
+		result.WriteString("[")
+		result.WriteString(requestID)
+		result.WriteString("] ")
+	}
+
+	result.WriteString("RPC ")
+	result.WriteString(req.Handler)
+	result.WriteString(" - ")
+	result.WriteString(status)
+	result.WriteString(" - ")
+	result.WriteString(latency.String())
+
+	return result.String()
+}
+
+// Logger returns a middleware that logs the handler name, reply status, request ID and latency.
+func Logger(l logger.Interface) rpc.Middleware {
+	return func(next rpc.Handler) rpc.Handler {
+		return func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+			start := time.Now()
+
+			response, err := next(ctx, req)
+
+			latency := time.Since(start)
+			l.Info(buildRequestMessage(ctx, req, status(err), latency))
+
+			return response, err
+		}
+	}
+}
+
+// status returns the reply status of err, or the error code for application errors.
+func status(err error) string {
+	if err == nil {
+		return rpc.Success
+	}
+
+	response := rpc.ErrorResponse(err)
+	if response.Status == rpc.StatusError {
+		return response.Header[rpc.HeaderErrorCode]
+	}
+
+	return response.Status
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// _unknownHandler replaces unregistered handler names, which come from the caller, to bound label cardinality.
+const _unknownHandler = "unknown"
+
+// Metrics returns a middleware that counts requests and observes latency per handler and status.
+// The collectors are registered in reg, e.g. prometheus.DefaultRegisterer which is served on /metrics;
+// routers built with the same registry share them.
+func Metrics(reg prometheus.Registerer, serviceName string) rpc.Middleware {
+	constLabels := prometheus.Labels{"service": serviceName}
+
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:        "rpc_requests_total",
+		Help:        "Count all RPC requests by handler and status.",
+		ConstLabels: constLabels,
+	}, []string{"handler", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "rpc_request_duration_seconds",
+		Help:        "Duration of RPC requests by handler and status.",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.DefBuckets,
+	}, []string{"handler", "status"})
+
+	requests = register(reg, requests)
+	duration = register(reg, duration)
+
+	return func(next rpc.Handler) rpc.Handler {
+		return func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+			start := time.Now()
+
+			response, err := next(ctx, req)
+
+			handler := req.Handler
+			if errors.Is(err, rpc.ErrBadHandler) {
+				handler = _unknownHandler
+			}
+
+			labels := prometheus.Labels{"handler": handler, "status": status(err)}
+
+			requests.With(labels).Inc()
+			duration.With(labels).Observe(time.Since(start).Seconds())
+
+			return response, err
+		}
+	}
+}
+
+// register registers collector in reg, or returns the collector already registered there in its place.
+func register[T prometheus.Collector](reg prometheus.Registerer, collector T) T {
+	err := reg.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+
+	panic(err)
+}
@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/controller/rpc/middleware"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter(reg prometheus.Registerer) *rpc.Router {
+	l := logger.New("error")
+
+	router := rpc.NewRouter()
+	router.Use(middleware.RequestID(), middleware.Logger(l), middleware.Metrics(reg, "test"), middleware.Recovery(l))
+
+	router.Handle("v1.requestID", func(ctx context.Context, _ *rpc.Request) (interface{}, error) {
+		return middleware.GetRequestID(ctx), nil
+	})
+	router.Handle("v1.panic", func(context.Context, *rpc.Request) (interface{}, error) {
+		panic("boom")
+	})
+
+	return router
+}
+
+func TestRecovery_RepliesInternalError(t *testing.T) {
+	t.Parallel()
+
+	resp, err := newRouter(prometheus.NewRegistry()).Serve(t.Context(), &rpc.Request{Handler: "v1.panic"})
+	require.ErrorIs(t, err, rpc.ErrInternalServer)
+	require.Equal(t, rpc.ErrInternalServer.Error(), resp.Status)
+	require.NotEmpty(t, resp.Header[middleware.RequestIDHeader])
+}
+
+func TestRequestID_Propagated(t *testing.T) {
+	t.Parallel()
+
+	resp, err := newRouter(prometheus.NewRegistry()).Serve(t.Context(), &rpc.Request{
+		Handler: "v1.requestID",
+		Header:  map[string]string{middleware.RequestIDHeader: "req-1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "req-1", resp.Header[middleware.RequestIDHeader])
+
+	var requestID string
+
+	require.NoError(t, resp.Decode(&requestID))
+	require.Equal(t, "req-1", requestID)
+}
+
+func TestMetrics_CountsByHandlerAndStatus(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	router := newRouter(reg)
+
+	for _, handler := range []string{"v1.requestID", "v1.requestID", "v1.panic", "v1.unknown", "v1.other"} {
+		_, _ = router.Serve(t.Context(), &rpc.Request{Handler: handler}) //nolint:errcheck // statuses are checked via metrics
+	}
+
+	expected := `
+# HELP rpc_requests_total Count all RPC requests by handler and status.
+# TYPE rpc_requests_total counter
+rpc_requests_total{handler="unknown",service="test",status="unregistered handler"} 2
+rpc_requests_total{handler="v1.panic",service="test",status="internal server error"} 1
+rpc_requests_total{handler="v1.requestID",service="test",status="success"} 2
+`
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "rpc_requests_total"))
+	require.Equal(t, 3, testutil.CollectAndCount(reg, "rpc_request_duration_seconds"))
+}
+
+func TestMetrics_SharedByRouters(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+
+	for range 2 {
+		_, err := newRouter(reg).Serve(t.Context(), &rpc.Request{Handler: "v1.requestID"})
+		require.NoError(t, err)
+	}
+
+	expected := `
+# HELP rpc_requests_total Count all RPC requests by handler and status.
+# TYPE rpc_requests_total counter
+rpc_requests_total{handler="v1.requestID",service="test",status="success"} 2
+`
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "rpc_requests_total"))
+}
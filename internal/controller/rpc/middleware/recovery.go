@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+)
+
+func buildPanicMessage(ctx context.Context, req *rpc.Request, err interface{}) string {
+	var result strings.Builder
+
+	if requestID := GetRequestID(ctx); requestID != "" {
+		result.WriteString("[")
+		result.WriteString(requestID)
+		result.WriteString("] ")
+	}
+
+	result.WriteString(req.Handler)
+	result.WriteString(" PANIC DETECTED: ")
+	result.WriteString(fmt.Sprintf("%v\n%s\n", err, debug.Stack()))
+
+	return result.String()
+}
+
+// Recovery turns a panic in a handler into an internal server error reply and logs the stack.
+func Recovery(l logger.Interface) rpc.Middleware {
+	return func(next rpc.Handler) rpc.Handler {
+		return func(ctx context.Context, req *rpc.Request) (response interface{}, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					l.Error(buildPanicMessage(ctx, req, r))
+
+					response, err = nil, fmt.Errorf("%w: panic: %v", rpc.ErrInternalServer, r)
+				}
+			}()
+
+			return next(ctx, req)
+		}
+	}
+}
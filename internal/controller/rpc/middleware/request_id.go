@@ -0,0 +1,38 @@
+// Package middleware implements handler middleware shared by the RabbitMQ and NATS RPC servers.
+package middleware
+
+import (
+	"context"
+
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the message header carrying the request ID, the same name as for REST.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// RequestID takes the request ID from the message headers or generates a new one,
+// stores it in the context and returns it in the reply headers.
+func RequestID() rpc.Middleware {
+	return func(next rpc.Handler) rpc.Handler {
+		return func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+			requestID := req.Header[RequestIDHeader]
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+
+			rpc.SetResponseHeader(ctx, RequestIDHeader, requestID)
+
+			return next(context.WithValue(ctx, requestIDKey{}, requestID), req)
+		}
+	}
+}
+
+// GetRequestID returns the request ID stored by RequestID, empty if there is none.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+
+	return requestID
+}
@@ -0,0 +1,35 @@
+// Package rpc registers the RPC handlers served on the RabbitMQ and NATS buses.
+package rpc
+
+import (
+	"github.com/evrone/go-clean-template/config"
+	"github.com/evrone/go-clean-template/internal/controller/rpc/middleware"
+	v1 "github.com/evrone/go-clean-template/internal/controller/rpc/v1"
+	"github.com/evrone/go-clean-template/internal/usecase"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	pkgrpc "github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewRouter -.
+func NewRouter(cfg *config.Config, t usecase.Translation, l logger.Interface) *pkgrpc.Router {
+	router := pkgrpc.NewRouter()
+
+	// Middleware — order matters: RequestID → Logger → Metrics → Recovery.
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(l))
+
+	// Prometheus metrics, served on the HTTP /metrics endpoint.
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.Metrics(prometheus.DefaultRegisterer, cfg.App.Name))
+	}
+
+	router.Use(middleware.Recovery(l))
+
+	// Routers.
+	{
+		v1.NewTranslationRoutes(router, t, l)
+	}
+
+	return router
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	grpcresponse "github.com/evrone/go-clean-template/internal/controller/grpc/v1/response"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"google.golang.org/protobuf/proto"
+)
+
+// TranslationHistory is encoded as entity.TranslationHistory in JSON and MessagePack
+// and as the gRPC GetHistoryResponse (docs/proto/v1) in Protobuf.
+type TranslationHistory struct {
+	entity.TranslationHistory
+}
+
+// NewTranslationHistory -.
+func NewTranslationHistory(translationHistory entity.TranslationHistory) TranslationHistory {
+	return TranslationHistory{TranslationHistory: translationHistory}
+}
+
+// Proto -.
+func (h TranslationHistory) Proto() proto.Message {
+	return grpcresponse.NewTranslationHistory(h.TranslationHistory)
+}
@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evrone/go-clean-template/internal/controller/rpc/v1/response"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+)
+
+func (r *V1) getHistory() rpc.Handler {
+	return func(ctx context.Context, _ *rpc.Request) (interface{}, error) {
+		translationHistory, err := r.t.History(ctx)
+		if err != nil {
+			r.l.Error(err, "rpc - V1 - getHistory")
+
+			return nil, fmt.Errorf("rpc - V1 - getHistory: %w", err)
+		}
+
+		return response.NewTranslationHistory(translationHistory), nil
+	}
+}
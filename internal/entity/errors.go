@@ -10,9 +10,10 @@ import (
 
 // AppError represents a structured application error with an HTTP status code.
 type AppError struct {
-	Code       string `json:"code"`
-	Message    string `json:"message"`
-	HTTPStatus int    `json:"-"`
+	Code       string            `json:"code"`
+	Message    string            `json:"message"`
+	Details    map[string]string `json:"details,omitempty"`
+	HTTPStatus int               `json:"-"`
 }
 
 // Error implements the error interface.
@@ -20,6 +21,32 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is an application error with the same code,
+// so copies made by WithDetails and errors received over RPC match the sentinels.
+func (e *AppError) Is(target error) bool {
+	coder, ok := target.(interface{ ErrorCode() string })
+
+	return ok && e.Code == coder.ErrorCode()
+}
+
+// ErrorCode returns the machine readable code, used to encode the error in RPC replies.
+func (e *AppError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorDetails returns additional context of the error, used to encode the error in RPC replies.
+func (e *AppError) ErrorDetails() map[string]string {
+	return e.Details
+}
+
+// WithDetails returns a copy of the error with details attached.
+func (e *AppError) WithDetails(details map[string]string) *AppError {
+	appErr := *e
+	appErr.Details = details
+
+	return &appErr
+}
+
 // Sentinel application errors.
 var (
 	ErrNotFound        = &AppError{Code: "NOT_FOUND", Message: "resource not found", HTTPStatus: http.StatusNotFound}
@@ -29,8 +56,17 @@ var (
 	ErrForbidden       = &AppError{Code: "FORBIDDEN", Message: "access denied", HTTPStatus: http.StatusForbidden}
 	ErrInternal        = &AppError{Code: "INTERNAL_ERROR", Message: "internal server error", HTTPStatus: http.StatusInternalServerError}
 	ErrExternalService = &AppError{Code: "EXTERNAL_SERVICE_ERROR", Message: "external service unavailable", HTTPStatus: http.StatusBadGateway}
+	// ErrProviderUnavailable is returned without calling the provider while its circuit breaker is open,
+	// with the seconds to wait in the DetailRetryAfter detail.
+	ErrProviderUnavailable = &AppError{Code: "PROVIDER_UNAVAILABLE", Message: "translation provider temporarily unavailable", HTTPStatus: http.StatusServiceUnavailable}
+	// ErrPlaceholderLost is returned when the provider dropped a placeholder of the text,
+	// named in the "placeholder" detail.
+	ErrPlaceholderLost = &AppError{Code: "PLACEHOLDER_LOST", Message: "translation lost a placeholder", HTTPStatus: http.StatusBadGateway}
 )
 
+// DetailRetryAfter is the AppError detail with the number of seconds after which the request can be retried.
+const DetailRetryAfter = "retry_after"
+
 // NewAppError creates a new AppError wrapping an underlying error.
 func NewAppError(appErr *AppError, underlying error) error {
 	return fmt.Errorf("%w: %w", appErr, underlying)
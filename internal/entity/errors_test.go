@@ -0,0 +1,39 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppError_WithDetailsMatchesSentinel(t *testing.T) {
+	t.Parallel()
+
+	err := entity.NewAppError(entity.ErrValidation.WithDetails(map[string]string{"field": "destination"}), errors.New("unknown language"))
+
+	require.ErrorIs(t, err, entity.ErrValidation)
+	require.NotErrorIs(t, err, entity.ErrBadRequest)
+	require.Equal(t, "destination", entity.GetAppError(err).Details["field"])
+	require.Empty(t, entity.ErrValidation.Details)
+}
+
+func TestAppError_SurvivesRPCReply(t *testing.T) {
+	t.Parallel()
+
+	err := entity.NewAppError(entity.ErrValidation.WithDetails(map[string]string{"field": "destination"}), errors.New("unknown language"))
+
+	remote := rpc.ErrorResponse(err).Err()
+
+	require.ErrorIs(t, remote, entity.ErrValidation)
+	require.NotErrorIs(t, remote, entity.ErrInternal)
+
+	var rpcErr *rpc.Error
+
+	require.ErrorAs(t, remote, &rpcErr)
+	require.Equal(t, entity.ErrValidation.Code, rpcErr.Code)
+	require.Equal(t, entity.ErrValidation.Message, rpcErr.Message)
+	require.Equal(t, map[string]string{"field": "destination"}, rpcErr.Details)
+}
@@ -0,0 +1,41 @@
+// Package entity defines main entities for business logic (services), data base mapping and
+// HTTP response objects if suitable. Each logic group entities in own file.
+package entity
+
+// Formats of glossary imports.
+const (
+	GlossaryFormatCSV = "csv"
+	GlossaryFormatTBX = "tbx"
+)
+
+// Glossary holds the terms translated the same way from Source into Destination.
+type Glossary struct {
+	ID          int64           `json:"id"                 example:"1"`
+	Name        string          `json:"name"               example:"Product names"`
+	Source      string          `json:"source"             example:"en"`
+	Destination string          `json:"destination"        example:"vi"`
+	Entries     []GlossaryEntry `json:"entries,omitempty"`
+}
+
+// GlossaryEntry forces the translation of Term, or keeps it untranslated with DoNotTranslate.
+// Terms match whole words, ignoring letter case unless CaseSensitive is set.
+type GlossaryEntry struct {
+	ID             int64  `json:"id"                example:"1"`
+	GlossaryID     int64  `json:"glossary_id"       example:"1"`
+	Term           string `json:"term"              example:"sign in"`
+	Translation    string `json:"translation"       example:"đăng nhập"`
+	DoNotTranslate bool   `json:"do_not_translate"  example:"false"`
+	CaseSensitive  bool   `json:"case_sensitive"    example:"false"`
+}
+
+// GlossaryList -.
+type GlossaryList struct {
+	Glossaries []Glossary `json:"glossaries"`
+}
+
+// GlossaryImport is a CSV or TBX file of terms added to a glossary.
+type GlossaryImport struct {
+	GlossaryID int64
+	Format     string
+	Content    []byte
+}
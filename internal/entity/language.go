@@ -0,0 +1,24 @@
+package entity
+
+// LanguageAuto is the source language that lets the provider detect the language of the text.
+const LanguageAuto = "auto"
+
+// Language is a language of the provider catalogue, identified by its BCP-47 tag.
+// Source and Destination tell in which directions the provider translates it.
+type Language struct {
+	Code        string `json:"code"         example:"pt-BR"`
+	Name        string `json:"name"         example:"Portuguese (Brazil)"`
+	Source      bool   `json:"source"       example:"true"`
+	Destination bool   `json:"destination"  example:"true"`
+}
+
+// LanguageList -.
+type LanguageList struct {
+	Languages []Language `json:"languages"`
+}
+
+// LanguageDetection is the language of a text and the confidence of the detection, from 0 to 1.
+type LanguageDetection struct {
+	Language   string  `json:"language"    example:"ru"`
+	Confidence float64 `json:"confidence"  example:"0.98"`
+}
@@ -0,0 +1,22 @@
+// Package entity defines main entities for business logic (services), data base mapping and
+// HTTP response objects if suitable. Each logic group entities in own file.
+package entity
+
+// Formats of TranslationFile.
+const (
+	FileFormatJSON  = "json"
+	FileFormatYAML  = "yaml"
+	FileFormatPO    = "po"
+	FileFormatXLIFF = "xliff"
+	FileFormatSRT   = "srt"
+	FileFormatVTT   = "vtt"
+)
+
+// TranslationFile is a resource file translated value by value into Destination.
+type TranslationFile struct {
+	Name        string
+	Format      string
+	Source      string
+	Destination string
+	Content     []byte
+}
@@ -2,10 +2,23 @@
 // HTTP response objects if suitable. Each logic group entities in own file.
 package entity
 
+// Input formats of Translation.Original; the markup of html and markdown inputs is not translated.
+const (
+	FormatText     = "text"
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
 // Translation -.
+// DetectedLanguage and DetectionConfidence are set when the source is LanguageAuto and the provider detected it.
+// GlossaryEntries lists the glossary entries found in Original and enforced in Translation.
 type Translation struct {
-	Source      string `json:"source"       example:"auto"`
-	Destination string `json:"destination"  example:"en"`
-	Original    string `json:"original"     example:"текст для перевода"`
-	Translation string `json:"translation"  example:"text for translation"`
+	Source              string          `json:"source"                          example:"auto"`
+	Destination         string          `json:"destination"                     example:"en"`
+	Original            string          `json:"original"                        example:"текст для перевода"`
+	Translation         string          `json:"translation"                     example:"text for translation"`
+	Format              string          `json:"format,omitempty"                example:"text"`
+	DetectedLanguage    string          `json:"detected_language,omitempty"     example:"ru"`
+	DetectionConfidence float64         `json:"detection_confidence,omitempty"  example:"0.98"`
+	GlossaryEntries     []GlossaryEntry `json:"glossary_entries,omitempty"`
 }
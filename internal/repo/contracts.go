@@ -16,8 +16,41 @@ type (
 		GetHistory(ctx context.Context, limit, offset int) ([]entity.Translation, error)
 	}
 
+	// GlossaryRepo stores glossaries and their entries; lookups of a missing glossary or entry
+	// return entity.ErrNotFound. CreateGlossary stores the glossary with its entries in one transaction.
+	// StoreEntries replaces the entries of the glossary with the same term.
+	GlossaryRepo interface {
+		CreateGlossary(context.Context, entity.Glossary) (entity.Glossary, error)
+		GetGlossary(ctx context.Context, id int64) (entity.Glossary, error)
+		GetGlossaries(context.Context) ([]entity.Glossary, error)
+		UpdateGlossary(context.Context, entity.Glossary) (entity.Glossary, error)
+		DeleteGlossary(ctx context.Context, id int64) error
+		StoreEntries(ctx context.Context, glossaryID int64, entries []entity.GlossaryEntry) ([]entity.GlossaryEntry, error)
+		DeleteEntry(ctx context.Context, glossaryID, entryID int64) error
+		// GetEntries returns the entries of the glossaries from source into destination, compared ignoring case.
+		GetEntries(ctx context.Context, source, destination string) ([]entity.GlossaryEntry, error)
+		// GetSources returns the source languages of the glossaries with entries into destination.
+		GetSources(ctx context.Context, destination string) ([]string, error)
+	}
+
 	// TranslationWebAPI -.
 	TranslationWebAPI interface {
 		Translate(ctx context.Context, translation entity.Translation) (entity.Translation, error)
+		LanguageDetector
+	}
+
+	// LanguageDetector detects the language of a text.
+	LanguageDetector interface {
+		DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error)
+	}
+
+	// TranslationCoalescer shares one provider call between instances translating the same request.
+	// Coalesce calls translate unless another instance holds key; then it waits for that result and shared is true.
+	TranslationCoalescer interface {
+		Coalesce(
+			ctx context.Context,
+			key string,
+			translate func(context.Context) (entity.Translation, error),
+		) (translation entity.Translation, shared bool, err error)
 	}
 )
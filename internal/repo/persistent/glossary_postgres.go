@@ -0,0 +1,387 @@
+package persistent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	_glossaryColumns = "id, name, source, destination"
+	_entryColumns    = "id, glossary_id, term, translation, do_not_translate, case_sensitive"
+
+	// _entryBatchSize keeps an insert of entries under the 65535 parameters of a Postgres statement.
+	_entryBatchSize = 1000
+)
+
+// GlossaryRepo -.
+type GlossaryRepo struct {
+	*postgres.Postgres
+}
+
+// NewGlossaryRepo -.
+func NewGlossaryRepo(pg *postgres.Postgres) *GlossaryRepo {
+	return &GlossaryRepo{pg}
+}
+
+// CreateGlossary stores the glossary with its entries in one transaction and returns them with their ids.
+// The terms of entries must be unique.
+func (r *GlossaryRepo) CreateGlossary(ctx context.Context, g entity.Glossary) (entity.Glossary, error) {
+	sql, args, err := r.Builder.
+		Insert("glossaries").
+		Columns("name, source, destination").
+		Values(g.Name, g.Source, g.Destination).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - CreateGlossary - r.Builder: %w", err)
+	}
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - CreateGlossary - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&g.ID)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - CreateGlossary - tx.QueryRow: %w", err)
+	}
+
+	g.Entries, err = r.upsertEntries(ctx, tx, g.ID, g.Entries)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - CreateGlossary - %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - CreateGlossary - tx.Commit: %w", err)
+	}
+
+	return g, nil
+}
+
+// GetGlossary returns the glossary with its entries, sorted by term.
+func (r *GlossaryRepo) GetGlossary(ctx context.Context, id int64) (entity.Glossary, error) {
+	sql, args, err := r.Builder.
+		Select(_glossaryColumns).
+		From("glossaries").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - GetGlossary - r.Builder: %w", err)
+	}
+
+	var g entity.Glossary
+
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&g.ID, &g.Name, &g.Source, &g.Destination)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - GetGlossary: glossary %d: %w", id, entity.ErrNotFound)
+	}
+
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - GetGlossary - r.Pool.QueryRow: %w", err)
+	}
+
+	g.Entries, err = queryEntries(ctx, r.Pool, r.Builder.
+		Select(_entryColumns).
+		From("glossary_entries").
+		Where(squirrel.Eq{"glossary_id": id}).
+		OrderBy("term"))
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - GetGlossary - %w", err)
+	}
+
+	return g, nil
+}
+
+// GetGlossaries returns the glossaries without their entries, sorted by id.
+func (r *GlossaryRepo) GetGlossaries(ctx context.Context) ([]entity.Glossary, error) {
+	sql, args, err := r.Builder.
+		Select(_glossaryColumns).
+		From("glossaries").
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetGlossaries - r.Builder: %w", err)
+	}
+
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetGlossaries - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	glossaries := make([]entity.Glossary, 0, _defaultEntityCap)
+
+	for rows.Next() {
+		g := entity.Glossary{}
+
+		err = rows.Scan(&g.ID, &g.Name, &g.Source, &g.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("GlossaryRepo - GetGlossaries - rows.Scan: %w", err)
+		}
+
+		glossaries = append(glossaries, g)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetGlossaries - rows.Err: %w", err)
+	}
+
+	return glossaries, nil
+}
+
+// UpdateGlossary sets the name and the languages of the glossary.
+func (r *GlossaryRepo) UpdateGlossary(ctx context.Context, g entity.Glossary) (entity.Glossary, error) {
+	sql, args, err := r.Builder.
+		Update("glossaries").
+		Set("name", g.Name).
+		Set("source", g.Source).
+		Set("destination", g.Destination).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": g.ID}).
+		ToSql()
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - UpdateGlossary - r.Builder: %w", err)
+	}
+
+	tag, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - UpdateGlossary - r.Pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.Glossary{}, fmt.Errorf("GlossaryRepo - UpdateGlossary: glossary %d: %w", g.ID, entity.ErrNotFound)
+	}
+
+	return g, nil
+}
+
+// DeleteGlossary deletes the glossary and its entries.
+func (r *GlossaryRepo) DeleteGlossary(ctx context.Context, id int64) error {
+	sql, args, err := r.Builder.
+		Delete("glossaries").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("GlossaryRepo - DeleteGlossary - r.Builder: %w", err)
+	}
+
+	tag, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("GlossaryRepo - DeleteGlossary - r.Pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("GlossaryRepo - DeleteGlossary: glossary %d: %w", id, entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// StoreEntries adds the entries to the glossary, replacing the entries with the same term,
+// and returns them with their ids. The terms of entries must be unique.
+func (r *GlossaryRepo) StoreEntries(
+	ctx context.Context,
+	glossaryID int64,
+	entries []entity.GlossaryEntry,
+) ([]entity.GlossaryEntry, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// Touching the glossary locks it against a concurrent delete and tells whether it exists.
+	touch, touchArgs, err := r.Builder.
+		Update("glossaries").
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": glossaryID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries - r.Builder: %w", err)
+	}
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	tag, err := tx.Exec(ctx, touch, touchArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries - tx.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries: glossary %d: %w", glossaryID, entity.ErrNotFound)
+	}
+
+	stored, err := r.upsertEntries(ctx, tx, glossaryID, entries)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries - %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - StoreEntries - tx.Commit: %w", err)
+	}
+
+	return stored, nil
+}
+
+// DeleteEntry deletes an entry of the glossary.
+func (r *GlossaryRepo) DeleteEntry(ctx context.Context, glossaryID, entryID int64) error {
+	sql, args, err := r.Builder.
+		Delete("glossary_entries").
+		Where(squirrel.Eq{"id": entryID, "glossary_id": glossaryID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("GlossaryRepo - DeleteEntry - r.Builder: %w", err)
+	}
+
+	tag, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("GlossaryRepo - DeleteEntry - r.Pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("GlossaryRepo - DeleteEntry: entry %d of glossary %d: %w", entryID, glossaryID, entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetEntries returns the entries of the glossaries from source into destination.
+func (r *GlossaryRepo) GetEntries(ctx context.Context, source, destination string) ([]entity.GlossaryEntry, error) {
+	entries, err := queryEntries(ctx, r.Pool, r.Builder.
+		Select("e.id, e.glossary_id, e.term, e.translation, e.do_not_translate, e.case_sensitive").
+		From("glossary_entries e").
+		Join("glossaries g ON g.id = e.glossary_id").
+		Where("LOWER(g.source) = LOWER(?) AND LOWER(g.destination) = LOWER(?)", source, destination).
+		OrderBy("e.id"))
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetEntries - %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetSources returns the source languages of the glossaries with entries into destination.
+func (r *GlossaryRepo) GetSources(ctx context.Context, destination string) ([]string, error) {
+	sql, args, err := r.Builder.
+		Select("DISTINCT g.source").
+		From("glossaries g").
+		Where("LOWER(g.destination) = LOWER(?)", destination).
+		Where("EXISTS (SELECT 1 FROM glossary_entries e WHERE e.glossary_id = g.id)").
+		OrderBy("g.source").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetSources - r.Builder: %w", err)
+	}
+
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetSources - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []string
+
+	for rows.Next() {
+		var source string
+
+		err = rows.Scan(&source)
+		if err != nil {
+			return nil, fmt.Errorf("GlossaryRepo - GetSources - rows.Scan: %w", err)
+		}
+
+		sources = append(sources, source)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("GlossaryRepo - GetSources - rows.Err: %w", err)
+	}
+
+	return sources, nil
+}
+
+// upsertEntries inserts the entries of the glossary in batches, replacing the entries with the same term,
+// and returns them with their ids.
+func (r *GlossaryRepo) upsertEntries(
+	ctx context.Context,
+	tx pgx.Tx,
+	glossaryID int64,
+	entries []entity.GlossaryEntry,
+) ([]entity.GlossaryEntry, error) {
+	stored := make([]entity.GlossaryEntry, 0, len(entries))
+
+	for batch := range slices.Chunk(entries, _entryBatchSize) {
+		insert := r.Builder.
+			Insert("glossary_entries").
+			Columns("glossary_id, term, translation, do_not_translate, case_sensitive").
+			Suffix("ON CONFLICT (glossary_id, term) DO UPDATE SET translation = EXCLUDED.translation, " +
+				"do_not_translate = EXCLUDED.do_not_translate, case_sensitive = EXCLUDED.case_sensitive " +
+				"RETURNING " + _entryColumns)
+
+		for _, e := range batch {
+			insert = insert.Values(glossaryID, e.Term, e.Translation, e.DoNotTranslate, e.CaseSensitive)
+		}
+
+		inserted, err := queryEntries(ctx, tx, insert)
+		if err != nil {
+			return nil, err
+		}
+
+		stored = append(stored, inserted...)
+	}
+
+	return stored, nil
+}
+
+// queryEntries runs a query returning the entry columns, with the pool or in a transaction.
+func queryEntries(
+	ctx context.Context,
+	q interface {
+		Query(context.Context, string, ...any) (pgx.Rows, error)
+	},
+	builder squirrel.Sqlizer,
+) ([]entity.GlossaryEntry, error) {
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("r.Builder: %w", err)
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.GlossaryEntry, 0, _defaultEntityCap)
+
+	for rows.Next() {
+		e := entity.GlossaryEntry{}
+
+		err = rows.Scan(&e.ID, &e.GlossaryID, &e.Term, &e.Translation, &e.DoNotTranslate, &e.CaseSensitive)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return entries, nil
+}
@@ -23,7 +23,7 @@ func New(pg *postgres.Postgres) *TranslationRepo {
 // GetHistory -.
 func (r *TranslationRepo) GetHistory(ctx context.Context, limit, offset int) ([]entity.Translation, error) {
 	builder := r.Builder.
-		Select("source, destination, original, translation").
+		Select("source, destination, original, translation, detected_language, detection_confidence").
 		From("history").
 		OrderBy("created_at DESC")
 
@@ -51,7 +51,9 @@ func (r *TranslationRepo) GetHistory(ctx context.Context, limit, offset int) ([]
 	for rows.Next() {
 		e := entity.Translation{}
 
-		err = rows.Scan(&e.Source, &e.Destination, &e.Original, &e.Translation)
+		err = rows.Scan(
+			&e.Source, &e.Destination, &e.Original, &e.Translation, &e.DetectedLanguage, &e.DetectionConfidence,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("TranslationRepo - GetHistory - rows.Scan: %w", err)
 		}
@@ -66,8 +68,8 @@ func (r *TranslationRepo) GetHistory(ctx context.Context, limit, offset int) ([]
 func (r *TranslationRepo) Store(ctx context.Context, t entity.Translation) error {
 	sql, args, err := r.Builder.
 		Insert("history").
-		Columns("source, destination, original, translation").
-		Values(t.Source, t.Destination, t.Original, t.Translation).
+		Columns("source, destination, original, translation, detected_language, detection_confidence").
+		Values(t.Source, t.Destination, t.Original, t.Translation, t.DetectedLanguage, t.DetectionConfidence).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("TranslationRepo - Store - r.Builder: %w", err)
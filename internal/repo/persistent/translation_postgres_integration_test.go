@@ -31,6 +31,7 @@ type TranslationRepoSuite struct {
 	container *tcpg.PostgresContainer // nil when using external DB
 	pg        *postgres.Postgres
 	repo      *persistent.TranslationRepo
+	glossary  *persistent.GlossaryRepo
 	ctx       context.Context
 }
 
@@ -76,7 +77,7 @@ func (s *TranslationRepoSuite) SetupSuite() {
 
 	s.pg = pg
 
-	// Create table schema (apply both migrations).
+	// Create table schema (apply all migrations).
 	_, err = pg.Pool.Exec(s.ctx, `
 		CREATE TABLE IF NOT EXISTS history(
 			id serial PRIMARY KEY,
@@ -98,12 +99,38 @@ func (s *TranslationRepoSuite) SetupSuite() {
 			IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='history' AND column_name='updated_at') THEN
 				ALTER TABLE history ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
 			END IF;
+			IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name='history' AND column_name='detected_language') THEN
+				ALTER TABLE history ADD COLUMN detected_language VARCHAR(35) NOT NULL DEFAULT '';
+				ALTER TABLE history ADD COLUMN detection_confidence DOUBLE PRECISION NOT NULL DEFAULT 0;
+			END IF;
 		END $$;
 		CREATE INDEX IF NOT EXISTS idx_history_created_at ON history(created_at DESC);
 	`)
 	require.NoError(s.T(), err)
 
+	_, err = pg.Pool.Exec(s.ctx, `
+		CREATE TABLE IF NOT EXISTS glossaries(
+			id serial PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			source VARCHAR(35) NOT NULL,
+			destination VARCHAR(35) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE TABLE IF NOT EXISTS glossary_entries(
+			id serial PRIMARY KEY,
+			glossary_id INTEGER NOT NULL REFERENCES glossaries(id) ON DELETE CASCADE,
+			term VARCHAR(255) NOT NULL,
+			translation VARCHAR(255) NOT NULL DEFAULT '',
+			do_not_translate BOOLEAN NOT NULL DEFAULT FALSE,
+			case_sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+			UNIQUE (glossary_id, term)
+		);
+	`)
+	require.NoError(s.T(), err)
+
 	s.repo = persistent.New(pg)
+	s.glossary = persistent.NewGlossaryRepo(pg)
 }
 
 func (s *TranslationRepoSuite) TearDownSuite() {
@@ -121,6 +148,9 @@ func (s *TranslationRepoSuite) SetupTest() {
 	// Clean table before each test.
 	_, err := s.pg.Pool.Exec(s.ctx, "DELETE FROM history")
 	require.NoError(s.T(), err)
+
+	_, err = s.pg.Pool.Exec(s.ctx, "DELETE FROM glossaries")
+	require.NoError(s.T(), err)
 }
 
 func (s *TranslationRepoSuite) TestStoreAndGetHistory() {
@@ -143,6 +173,26 @@ func (s *TranslationRepoSuite) TestStoreAndGetHistory() {
 	require.Equal(s.T(), "xin chào thế giới", history[0].Translation)
 	require.Equal(s.T(), "en", history[0].Source)
 	require.Equal(s.T(), "vi", history[0].Destination)
+	require.Empty(s.T(), history[0].DetectedLanguage)
+}
+
+func (s *TranslationRepoSuite) TestStoreDetectedLanguage() {
+	err := s.repo.Store(s.ctx, entity.Translation{
+		Source:              "auto",
+		Destination:         "en",
+		Original:            "xin chào",
+		Translation:         "hello",
+		DetectedLanguage:    "vi",
+		DetectionConfidence: 0.96,
+	})
+	require.NoError(s.T(), err)
+
+	history, err := s.repo.GetHistory(s.ctx, 100, 0)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), history, 1)
+	require.Equal(s.T(), "auto", history[0].Source)
+	require.Equal(s.T(), "vi", history[0].DetectedLanguage)
+	require.InDelta(s.T(), 0.96, history[0].DetectionConfidence, 1e-9)
 }
 
 func (s *TranslationRepoSuite) TestGetHistoryEmpty() {
@@ -233,6 +283,81 @@ func (s *TranslationRepoSuite) TestGetHistoryNoLimitNoOffset() {
 	require.Len(s.T(), history, 3)
 }
 
+func (s *TranslationRepoSuite) TestGlossaryEntries() {
+	g, err := s.glossary.CreateGlossary(s.ctx, entity.Glossary{
+		Name:        "Product",
+		Source:      "en",
+		Destination: "vi",
+		Entries: []entity.GlossaryEntry{
+			{Term: "invoice", Translation: "biên lai"},
+			{Term: "Acme Cloud", DoNotTranslate: true},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.NotZero(s.T(), g.ID)
+
+	stored := g.Entries
+	require.Len(s.T(), stored, 2)
+
+	// An entry with the same term replaces the stored one.
+	_, err = s.glossary.StoreEntries(s.ctx, g.ID, []entity.GlossaryEntry{{Term: "invoice", Translation: "hóa đơn"}})
+	require.NoError(s.T(), err)
+
+	entries, err := s.glossary.GetEntries(s.ctx, "EN", "vi")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), entries, 2)
+	require.Equal(s.T(), "hóa đơn", entries[0].Translation)
+
+	entries, err = s.glossary.GetEntries(s.ctx, "en", "fr")
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), entries)
+
+	sources, err := s.glossary.GetSources(s.ctx, "VI")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []string{"en"}, sources)
+
+	err = s.glossary.DeleteEntry(s.ctx, g.ID, stored[1].ID)
+	require.NoError(s.T(), err)
+
+	got, err := s.glossary.GetGlossary(s.ctx, g.ID)
+	require.NoError(s.T(), err)
+	invoice := stored[0]
+	invoice.Translation = "hóa đơn"
+	require.Equal(s.T(), []entity.GlossaryEntry{invoice}, got.Entries)
+}
+
+func (s *TranslationRepoSuite) TestGlossaryNotFound() {
+	_, err := s.glossary.GetGlossary(s.ctx, 1<<30)
+	require.ErrorIs(s.T(), err, entity.ErrNotFound)
+
+	_, err = s.glossary.StoreEntries(s.ctx, 1<<30, []entity.GlossaryEntry{{Term: "invoice", Translation: "hóa đơn"}})
+	require.ErrorIs(s.T(), err, entity.ErrNotFound)
+
+	err = s.glossary.DeleteGlossary(s.ctx, 1<<30)
+	require.ErrorIs(s.T(), err, entity.ErrNotFound)
+}
+
+func (s *TranslationRepoSuite) TestCreateGlossaryRollsBack() {
+	// Entries with the same term fail the insert, the glossary is not stored either.
+	_, err := s.glossary.CreateGlossary(s.ctx, entity.Glossary{
+		Name:        "Broken",
+		Source:      "en",
+		Destination: "de",
+		Entries: []entity.GlossaryEntry{
+			{Term: "invoice", Translation: "Rechnung"},
+			{Term: "invoice", Translation: "Beleg"},
+		},
+	})
+	require.Error(s.T(), err)
+
+	glossaries, err := s.glossary.GetGlossaries(s.ctx)
+	require.NoError(s.T(), err)
+
+	for _, g := range glossaries {
+		require.NotEqual(s.T(), "Broken", g.Name)
+	}
+}
+
 func TestTranslationRepoSuite(t *testing.T) {
 	suite.Run(t, new(TranslationRepoSuite))
 }
@@ -0,0 +1,137 @@
+package persistent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	pkgredis "github.com/evrone/go-clean-template/pkg/redis"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	_lockPrefix   = "translation:lock:"
+	_resultPrefix = "translation:result:"
+
+	_defaultPollInterval = 50 * time.Millisecond
+)
+
+// _unlock deletes the lock only if this instance still holds it.
+var _unlock = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`) //nolint:gochecknoglobals,lll // compiled once
+
+// TranslationCoalescer coordinates replicas with a short Redis lock: the instance which sets the lock
+// calls the provider and publishes the result for lockTTL, the others poll for it.
+// If the holder fails or the lock expires, a waiter takes the lock and calls the provider itself.
+type TranslationCoalescer struct {
+	*pkgredis.Redis
+
+	lockTTL      time.Duration
+	pollInterval time.Duration
+}
+
+// NewCoalescer -.
+func NewCoalescer(rd *pkgredis.Redis, lockTTL time.Duration) *TranslationCoalescer {
+	return &TranslationCoalescer{
+		Redis:        rd,
+		lockTTL:      lockTTL,
+		pollInterval: _defaultPollInterval,
+	}
+}
+
+// Coalesce -.
+// Redis errors do not fail the request: the provider is called without coordination.
+func (c *TranslationCoalescer) Coalesce(
+	ctx context.Context,
+	key string,
+	translate func(context.Context) (entity.Translation, error),
+) (entity.Translation, bool, error) {
+	token := uuid.NewString()
+
+	locked, err := c.Client.SetNX(ctx, _lockPrefix+key, token, c.lockTTL).Result()
+	if err != nil || locked {
+		translation, err := c.lead(ctx, key, token, locked, translate)
+
+		return translation, false, err
+	}
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	// Another instance holds the lock: wait for its result, or for the lock to be released without one.
+	for {
+		select {
+		case <-ctx.Done():
+			return entity.Translation{}, false, fmt.Errorf("TranslationCoalescer - Coalesce: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		translation, found, err := c.result(ctx, key)
+		if err != nil {
+			translation, err = translate(ctx)
+
+			return translation, false, err
+		}
+
+		if found {
+			return translation, true, nil
+		}
+
+		locked, err = c.Client.SetNX(ctx, _lockPrefix+key, token, c.lockTTL).Result()
+		if err != nil || locked {
+			translation, err := c.lead(ctx, key, token, locked, translate)
+
+			return translation, false, err
+		}
+	}
+}
+
+func (c *TranslationCoalescer) lead(
+	ctx context.Context,
+	key, token string,
+	locked bool,
+	translate func(context.Context) (entity.Translation, error),
+) (entity.Translation, error) {
+	translation, err := translate(ctx)
+
+	if !locked {
+		return translation, err
+	}
+
+	// Publish and unlock even if the caller's context has just ended.
+	ctx = context.WithoutCancel(ctx)
+
+	if err == nil {
+		data, marshalErr := json.Marshal(translation)
+		if marshalErr == nil {
+			_ = c.Client.Set(ctx, _resultPrefix+key, data, c.lockTTL).Err()
+		}
+	}
+
+	_ = _unlock.Run(ctx, c.Client, []string{_lockPrefix + key}, token).Err()
+
+	return translation, err
+}
+
+func (c *TranslationCoalescer) result(ctx context.Context, key string) (entity.Translation, bool, error) {
+	data, err := c.Client.Get(ctx, _resultPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return entity.Translation{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Translation{}, false, fmt.Errorf("TranslationCoalescer - result - c.Client.Get: %w", err)
+	}
+
+	var translation entity.Translation
+
+	err = json.Unmarshal(data, &translation)
+	if err != nil {
+		return entity.Translation{}, false, fmt.Errorf("TranslationCoalescer - result - json.Unmarshal: %w", err)
+	}
+
+	return translation, true, nil
+}
@@ -0,0 +1,148 @@
+//go:build integration
+
+package persistent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo/persistent"
+	pkgredis "github.com/evrone/go-clean-template/pkg/redis"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+const redisImage = "redis:7.4-alpine"
+
+var errProvider = errors.New("provider failed")
+
+type TranslationCoalescerSuite struct {
+	suite.Suite
+	container *tcredis.RedisContainer // nil when using external Redis
+	rd        *pkgredis.Redis
+	ctx       context.Context
+}
+
+func (s *TranslationCoalescerSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	var connStr string
+
+	// If TEST_REDIS_URL is set (Docker compose), use it directly.
+	// Otherwise, spin up a testcontainer for local development.
+	if redisURL := os.Getenv("TEST_REDIS_URL"); redisURL != "" {
+		connStr = redisURL
+	} else {
+		container, err := tcredis.Run(s.ctx, redisImage)
+		require.NoError(s.T(), err)
+
+		s.container = container
+
+		cs, err := container.ConnectionString(s.ctx)
+		require.NoError(s.T(), err)
+
+		connStr = cs
+	}
+
+	rd, err := pkgredis.New(connStr, pkgredis.ConnAttempts(10), pkgredis.ConnTimeout(time.Second))
+	require.NoError(s.T(), err)
+
+	s.rd = rd
+}
+
+func (s *TranslationCoalescerSuite) TearDownSuite() {
+	if s.rd != nil {
+		require.NoError(s.T(), s.rd.Close())
+	}
+
+	if s.container != nil {
+		require.NoError(s.T(), s.container.Terminate(s.ctx))
+	}
+}
+
+func (s *TranslationCoalescerSuite) SetupTest() {
+	require.NoError(s.T(), s.rd.Client.FlushAll(s.ctx).Err())
+}
+
+// TestReplicasShareOneCall uses a coalescer per simulated replica.
+func (s *TranslationCoalescerSuite) TestReplicasShareOneCall() {
+	const replicas = 4
+
+	var calls atomic.Int32
+
+	translate := func(context.Context) (entity.Translation, error) {
+		calls.Add(1)
+		time.Sleep(200 * time.Millisecond)
+
+		return entity.Translation{Translation: "text"}, nil
+	}
+
+	var (
+		wg     sync.WaitGroup
+		shared atomic.Int32
+	)
+
+	for range replicas {
+		coalescer := persistent.NewCoalescer(s.rd, 5*time.Second)
+
+		wg.Go(func() {
+			translation, isShared, err := coalescer.Coalesce(s.ctx, "key", translate)
+			s.NoError(err)
+			s.Equal("text", translation.Translation)
+
+			if isShared {
+				shared.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	s.EqualValues(1, calls.Load())
+	s.EqualValues(replicas-1, shared.Load())
+
+	// The lock is released: a later request calls the provider again.
+	_, isShared, err := persistent.NewCoalescer(s.rd, 5*time.Second).Coalesce(s.ctx, "key", translate)
+	s.Require().NoError(err)
+	s.False(isShared)
+	s.EqualValues(2, calls.Load())
+}
+
+func (s *TranslationCoalescerSuite) TestWaiterTakesOverAfterFailure() {
+	coalescer := persistent.NewCoalescer(s.rd, 5*time.Second)
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		_, _, err := coalescer.Coalesce(s.ctx, "key", func(context.Context) (entity.Translation, error) {
+			close(started)
+			time.Sleep(100 * time.Millisecond)
+
+			return entity.Translation{}, errProvider
+		})
+		s.ErrorIs(err, errProvider)
+	})
+
+	<-started
+
+	translation, isShared, err := coalescer.Coalesce(s.ctx, "key", func(context.Context) (entity.Translation, error) {
+		return entity.Translation{Translation: "text"}, nil
+	})
+	s.Require().NoError(err)
+	s.False(isShared)
+	s.Equal("text", translation.Translation)
+
+	wg.Wait()
+}
+
+func TestTranslationCoalescerSuite(t *testing.T) {
+	suite.Run(t, new(TranslationCoalescerSuite))
+}
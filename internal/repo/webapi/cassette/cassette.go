@@ -0,0 +1,245 @@
+// Package cassette records translation provider HTTP calls to a file and replays them in tests,
+// so tests exercise real response shapes without network access.
+//
+// Requests are matched strictly on method, path, language pair (sl, tl) and text (q),
+// read from the query or from a form body;
+// a replayed request without a recorded interaction fails the test. Interactions with
+// the same key are served in the recorded order.
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+// EnvMode selects the mode of ModeFromEnv: "record" records, anything else replays.
+const EnvMode = "CASSETTE_MODE"
+
+// Mode -.
+type Mode int
+
+const (
+	// ModeReplay serves recorded responses and fails on unmatched requests.
+	ModeReplay Mode = iota
+	// ModeRecord calls the real provider and saves the interactions when the test ends.
+	ModeRecord
+)
+
+// ErrUnmatched is returned by RoundTrip for a request without a recorded interaction.
+var ErrUnmatched = errors.New("cassette: no recorded interaction")
+
+// ModeFromEnv returns ModeRecord if CASSETTE_MODE=record.
+func ModeFromEnv() Mode {
+	if os.Getenv(EnvMode) == "record" {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+// Request identifies an interaction.
+type Request struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Text        string `json:"text"`
+}
+
+// Response -.
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Interaction -.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recorder is an http.RoundTripper which records or replays interactions.
+type Recorder struct {
+	t    testing.TB
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	served       []bool
+}
+
+// New loads the cassette at path in replay mode; in record mode the cassette is written when the test ends.
+// next performs the real calls in record mode, http.DefaultTransport if nil.
+func New(t testing.TB, path string, mode Mode, next http.RoundTripper) *Recorder {
+	t.Helper()
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{t: t, path: path, mode: mode, next: next}
+
+	if mode == ModeRecord {
+		t.Cleanup(r.save)
+
+		return r
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cassette - New - os.ReadFile: %v", err)
+	}
+
+	err = json.Unmarshal(data, &r.interactions)
+	if err != nil {
+		t.Fatalf("cassette - New - json.Unmarshal(%s): %v", path, err)
+	}
+
+	r.served = make([]bool, len(r.interactions))
+
+	return r
+}
+
+// Client returns an HTTP client using the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip -.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := requestOf(req)
+	if err != nil {
+		return nil, fmt.Errorf("cassette - Recorder - RoundTrip - requestOf: %w", err)
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, key)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.served[i] || interaction.Request != key {
+			continue
+		}
+
+		r.served[i] = true
+
+		return interaction.Response.http(req), nil
+	}
+
+	r.t.Errorf("cassette %s: unmatched request %+v", r.path, key)
+
+	return nil, fmt.Errorf("%w: %+v", ErrUnmatched, key)
+}
+
+func (r *Recorder) record(req *http.Request, key Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // transport errors are passed through unchanged
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette - Recorder - record - io.ReadAll: %w", err)
+	}
+
+	recorded := Response{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(body),
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: key, Response: recorded})
+	r.mu.Unlock()
+
+	return recorded.http(req), nil
+}
+
+func (r *Recorder) save() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		r.t.Errorf("cassette - Recorder - save - json.MarshalIndent: %v", err)
+
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755) //nolint:mnd // rwxr-xr-x
+	if err == nil {
+		err = os.WriteFile(r.path, append(data, '\n'), 0o644) //nolint:mnd,gosec // cassettes are committed
+	}
+
+	if err != nil {
+		r.t.Errorf("cassette - Recorder - save: %v", err)
+	}
+}
+
+// requestOf returns the key of req. A form body is read and restored, so the request can still be sent.
+func requestOf(req *http.Request) (Request, error) {
+	values := req.URL.Query()
+
+	if req.Body != nil && req.Body != http.NoBody && req.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return Request{}, fmt.Errorf("io.ReadAll: %w", err)
+		}
+
+		req.Body.Close() //nolint:errcheck // fully read
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return Request{}, fmt.Errorf("url.ParseQuery: %w", err)
+		}
+
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+
+	return Request{
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Source:      values.Get("sl"),
+		Destination: values.Get("tl"),
+		Text:        values.Get("q"),
+	}, nil
+}
+
+func (r Response) http(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,180 @@
+package cassette_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/repo/webapi/cassette"
+	"github.com/stretchr/testify/require"
+)
+
+// failures records Errorf calls instead of failing the test.
+type failures struct {
+	testing.TB
+
+	errors []string
+}
+
+func (f *failures) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func get(t *testing.T, client *http.Client, server, source, destination, text string) (string, error) {
+	t.Helper()
+
+	query := url.Values{"sl": {source}, "tl": {destination}, "q": {text}}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server+"/translate?"+query.Encode(), http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err //nolint:wrapcheck // test helper
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return fmt.Sprintf("%d %s", resp.StatusCode, body), nil
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"text":%q,"call":%d}`, r.URL.Query().Get("q"), calls)
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	t.Run("record", func(t *testing.T) {
+		client := cassette.New(t, path, cassette.ModeRecord, nil).Client()
+
+		for _, text := range []string{"one", "two", "one"} {
+			_, err := get(t, client, server.URL, "en", "de", text)
+			require.NoError(t, err)
+		}
+	})
+
+	require.Equal(t, 3, calls)
+
+	t.Run("replay", func(t *testing.T) {
+		client := cassette.New(t, path, cassette.ModeReplay, nil).Client()
+
+		// The same key is served in the recorded order.
+		for _, tt := range []struct{ text, want string }{
+			{"one", `200 {"text":"one","call":1}`},
+			{"one", `200 {"text":"one","call":3}`},
+			{"two", `200 {"text":"two","call":2}`},
+		} {
+			got, err := get(t, client, "http://replay.invalid", "en", "de", tt.text)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		}
+	})
+
+	require.Equal(t, 3, calls)
+}
+
+func TestRecorder_FormBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s %s", r.Method, r.PostFormValue("q"))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	post := func(t *testing.T, client *http.Client, server, text string) (string, error) {
+		t.Helper()
+
+		body := strings.NewReader(url.Values{"q": {text}}.Encode())
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server+"/translate?sl=en&tl=de", body)
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err //nolint:wrapcheck // test helper
+		}
+		defer resp.Body.Close()
+
+		got, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(got), nil
+	}
+
+	t.Run("record", func(t *testing.T) {
+		// The body read for the key is still sent to the provider.
+		got, err := post(t, cassette.New(t, path, cassette.ModeRecord, nil).Client(), server.URL, "Grüße")
+		require.NoError(t, err)
+		require.Equal(t, "POST Grüße", got)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		client := cassette.New(t, path, cassette.ModeReplay, nil).Client()
+
+		got, err := post(t, client, "http://replay.invalid", "Grüße")
+		require.NoError(t, err)
+		require.Equal(t, "POST Grüße", got)
+
+		tb := &failures{TB: t}
+
+		_, err = post(t, cassette.New(tb, path, cassette.ModeReplay, nil).Client(), "http://replay.invalid", "Gruß")
+		require.ErrorIs(t, err, cassette.ErrUnmatched)
+		require.Len(t, tb.errors, 1)
+	})
+}
+
+func TestRecorder_Unmatched(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	t.Run("record", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := get(t, cassette.New(t, path, cassette.ModeRecord, nil).Client(), server.URL, "en", "de", "text")
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		text        string
+	}{
+		{name: "language pair", source: "en", destination: "fr", text: "text"},
+		{name: "text", source: "en", destination: "de", text: "Text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &failures{TB: t}
+			client := cassette.New(tb, path, cassette.ModeReplay, nil).Client()
+
+			_, err := get(t, client, "http://replay.invalid", tt.source, tt.destination, tt.text)
+			require.ErrorIs(t, err, cassette.ErrUnmatched)
+			require.Len(t, tb.errors, 1)
+		})
+	}
+}
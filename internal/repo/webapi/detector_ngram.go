@@ -0,0 +1,255 @@
+package webapi
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// _unseenWeight is the count given to the trigrams missing from a language sample.
+const _unseenWeight = 0.5
+
+// ErrUnknownLanguage is returned when the text has no letters or only letters of scripts without a language sample.
+var ErrUnknownLanguage = errors.New("unknown language")
+
+//go:embed corpus/*.txt
+var _corpus embed.FS
+
+// _scriptLanguages are the scripts written by one language of the catalogue, which decide alone.
+// Han is Chinese unless the text also has kana: hiragana, katakana and Han come first, in that order.
+//
+//nolint:gochecknoglobals // read-only table
+var _scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"}, {unicode.Katakana, "ja"}, {unicode.Han, "zh-CN"}, {unicode.Hangul, "ko"},
+	{unicode.Thai, "th"}, {unicode.Greek, "el"}, {unicode.Hebrew, "he"}, {unicode.Georgian, "ka"},
+	{unicode.Armenian, "hy"}, {unicode.Khmer, "km"}, {unicode.Lao, "lo"}, {unicode.Myanmar, "my"},
+	{unicode.Ethiopic, "am"}, {unicode.Bengali, "bn"}, {unicode.Gujarati, "gu"}, {unicode.Gurmukhi, "pa"},
+	{unicode.Tamil, "ta"}, {unicode.Telugu, "te"}, {unicode.Kannada, "kn"}, {unicode.Malayalam, "ml"},
+	{unicode.Sinhala, "si"},
+}
+
+// _sampleScripts are the scripts of the language samples, compared by trigrams.
+//
+//nolint:gochecknoglobals // read-only table
+var _sampleScripts = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Arabic, unicode.Devanagari}
+
+// ngramProfile holds the log-probabilities of the letter trigrams of a language sample.
+type ngramProfile struct {
+	language string
+	script   *unicode.RangeTable
+	logProb  map[string]float64
+	unseen   float64
+}
+
+// NGramDetector detects languages without network calls, for the offline provider and as a fallback.
+// Scripts written by one language decide alone; other texts are compared with the letter trigrams
+// of a short sample of each language, embedded from corpus/*.txt.
+type NGramDetector struct {
+	profiles []ngramProfile
+}
+
+// NewNGramDetector builds the trigram profiles of the embedded samples.
+func NewNGramDetector() *NGramDetector {
+	d := &NGramDetector{}
+
+	entries, _ := _corpus.ReadDir("corpus")
+	for _, e := range entries {
+		sample, _ := _corpus.ReadFile(path.Join("corpus", e.Name()))
+		d.profiles = append(d.profiles, newNGramProfile(strings.TrimSuffix(e.Name(), ".txt"), string(sample)))
+	}
+
+	return d
+}
+
+func newNGramProfile(language, sample string) ngramProfile {
+	counts := trigrams(sample)
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	// Additive smoothing over the seen trigrams and as many unseen ones.
+	denominator := float64(total) + _unseenWeight*float64(2*len(counts))
+
+	p := ngramProfile{
+		language: language,
+		script:   dominantScript(sample, _sampleScripts),
+		logProb:  make(map[string]float64, len(counts)),
+		unseen:   math.Log(_unseenWeight / denominator),
+	}
+
+	for trigram, n := range counts {
+		p.logProb[trigram] = math.Log((float64(n) + _unseenWeight) / denominator)
+	}
+
+	return p
+}
+
+// DetectLanguage returns the most likely language of the text and its posterior probability
+// among the languages written in the same script, lowered by the share of letters in other scripts.
+func (d *NGramDetector) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	if err := ctx.Err(); err != nil {
+		return entity.LanguageDetection{}, fmt.Errorf("NGramDetector - DetectLanguage: %w", err)
+	}
+
+	scripts := make([]*unicode.RangeTable, 0, len(_scriptLanguages)+len(_sampleScripts))
+	for _, s := range _scriptLanguages {
+		scripts = append(scripts, s.script)
+	}
+
+	scripts = append(scripts, _sampleScripts...)
+
+	counts, letters := scriptCounts(text, scripts)
+	if letters == 0 {
+		return entity.LanguageDetection{}, fmt.Errorf("NGramDetector - DetectLanguage: %w", ErrUnknownLanguage)
+	}
+
+	best := 0
+
+	for i, n := range counts {
+		if n > counts[best] {
+			best = i
+		}
+	}
+
+	share := float64(counts[best]) / float64(letters)
+
+	if best < len(_scriptLanguages) {
+		language := _scriptLanguages[best].language
+
+		// Kana marks Japanese even when most letters are Han.
+		if kana := counts[0] + counts[1]; kana > 0 {
+			language = "ja"
+			share = float64(kana+counts[2]) / float64(letters)
+		}
+
+		return entity.LanguageDetection{Language: language, Confidence: share}, nil
+	}
+
+	detection, ok := d.compare(text, scripts[best])
+	if !ok {
+		return entity.LanguageDetection{}, fmt.Errorf("NGramDetector - DetectLanguage: %w", ErrUnknownLanguage)
+	}
+
+	detection.Confidence *= share
+
+	return detection, nil
+}
+
+// compare scores the trigrams of the text against the samples written in script.
+func (d *NGramDetector) compare(text string, script *unicode.RangeTable) (entity.LanguageDetection, bool) {
+	counts := trigrams(text)
+
+	var (
+		scores []float64
+		names  []string
+	)
+
+	for _, p := range d.profiles {
+		if p.script != script {
+			continue
+		}
+
+		score := 0.0
+
+		for trigram, n := range counts {
+			logProb, ok := p.logProb[trigram]
+			if !ok {
+				logProb = p.unseen
+			}
+
+			score += float64(n) * logProb
+		}
+
+		scores = append(scores, score)
+		names = append(names, p.language)
+	}
+
+	if len(scores) == 0 {
+		return entity.LanguageDetection{}, false
+	}
+
+	best := 0
+
+	for i, score := range scores {
+		if score > scores[best] {
+			best = i
+		}
+	}
+
+	// Posterior of the best language, the samples being equally likely.
+	sum := 0.0
+	for _, score := range scores {
+		sum += math.Exp(score - scores[best])
+	}
+
+	return entity.LanguageDetection{Language: names[best], Confidence: 1 / sum}, true
+}
+
+// trigrams counts the letter trigrams of the lowercase words of text, padded with a space on both sides.
+func trigrams(text string) map[string]int {
+	counts := map[string]int{}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r) && !unicode.Is(unicode.Mc, r)
+	})
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	return counts
+}
+
+// scriptCounts counts the letters of text in each script and in total.
+func scriptCounts(text string, scripts []*unicode.RangeTable) ([]int, int) {
+	counts := make([]int, len(scripts))
+	letters := 0
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+
+		for i, s := range scripts {
+			if unicode.Is(s, r) {
+				counts[i]++
+
+				break
+			}
+		}
+	}
+
+	return counts, letters
+}
+
+// dominantScript returns the script of most letters of text among scripts.
+func dominantScript(text string, scripts []*unicode.RangeTable) *unicode.RangeTable {
+	counts, _ := scriptCounts(text, scripts)
+
+	best := 0
+
+	for i, n := range counts {
+		if n > counts[best] {
+			best = i
+		}
+	}
+
+	return scripts[best]
+}
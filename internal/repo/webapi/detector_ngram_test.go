@@ -0,0 +1,44 @@
+package webapi_test
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNGramDetector(t *testing.T) {
+	t.Parallel()
+
+	detector := webapi.NewNGramDetector()
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "The quick brown fox jumps over the lazy dog and runs away into the forest.", expected: "en"},
+		{text: "Le renard brun saute par-dessus le chien paresseux et s'enfuit dans la forêt.", expected: "fr"},
+		{text: "Der schnelle braune Fuchs springt über den faulen Hund und läuft in den Wald.", expected: "de"},
+		{text: "El rápido zorro marrón salta sobre el perro perezoso y corre hacia el bosque.", expected: "es"},
+		{text: "Быстрая коричневая лиса прыгает через ленивую собаку и убегает в лес.", expected: "ru"},
+		{text: "Con cáo nâu nhanh nhẹn nhảy qua con chó lười và chạy vào rừng.", expected: "vi"},
+		{text: "素早い茶色の狐が怠け者の犬を飛び越える。", expected: "ja"},
+		{text: "敏捷的棕色狐狸跳过了懒狗。", expected: "zh-CN"},
+		{text: "빠른 갈색 여우가 게으른 개를 뛰어넘는다.", expected: "ko"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+
+			detection, err := detector.DetectLanguage(t.Context(), tt.text)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, detection.Language)
+			require.Greater(t, detection.Confidence, 0.5)
+			require.LessOrEqual(t, detection.Confidence, 1.0)
+		})
+	}
+
+	_, err := detector.DetectLanguage(t.Context(), "42 + 7 = 49")
+	require.ErrorIs(t, err, webapi.ErrUnknownLanguage)
+}
@@ -0,0 +1,61 @@
+package webapi
+
+import (
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// _googleLanguages lists the BCP-47 tags and English names of the languages of Google Translate.
+//
+//nolint:gochecknoglobals // read-only table
+var _googleLanguages = [][2]string{
+	{"af", "Afrikaans"}, {"ak", "Twi"}, {"am", "Amharic"}, {"ar", "Arabic"}, {"as", "Assamese"},
+	{"ay", "Aymara"}, {"az", "Azerbaijani"}, {"be", "Belarusian"}, {"bg", "Bulgarian"}, {"bho", "Bhojpuri"},
+	{"bm", "Bambara"}, {"bn", "Bengali"}, {"bs", "Bosnian"}, {"ca", "Catalan"}, {"ceb", "Cebuano"},
+	{"ckb", "Kurdish (Sorani)"}, {"co", "Corsican"}, {"cs", "Czech"}, {"cy", "Welsh"}, {"da", "Danish"},
+	{"de", "German"}, {"doi", "Dogri"}, {"dv", "Dhivehi"}, {"ee", "Ewe"}, {"el", "Greek"},
+	{"en", "English"}, {"eo", "Esperanto"}, {"es", "Spanish"}, {"et", "Estonian"}, {"eu", "Basque"},
+	{"fa", "Persian"}, {"fi", "Finnish"}, {"fr", "French"}, {"fy", "Frisian"}, {"ga", "Irish"},
+	{"gd", "Scots Gaelic"}, {"gl", "Galician"}, {"gn", "Guarani"}, {"gom", "Konkani"}, {"gu", "Gujarati"},
+	{"ha", "Hausa"}, {"haw", "Hawaiian"}, {"he", "Hebrew"}, {"hi", "Hindi"}, {"hmn", "Hmong"},
+	{"hr", "Croatian"}, {"ht", "Haitian Creole"}, {"hu", "Hungarian"}, {"hy", "Armenian"}, {"id", "Indonesian"},
+	{"ig", "Igbo"}, {"ilo", "Ilocano"}, {"is", "Icelandic"}, {"it", "Italian"}, {"ja", "Japanese"},
+	{"jv", "Javanese"}, {"ka", "Georgian"}, {"kk", "Kazakh"}, {"km", "Khmer"}, {"kn", "Kannada"},
+	{"ko", "Korean"}, {"kri", "Krio"}, {"ku", "Kurdish (Kurmanji)"}, {"ky", "Kyrgyz"}, {"la", "Latin"},
+	{"lb", "Luxembourgish"}, {"lg", "Luganda"}, {"ln", "Lingala"}, {"lo", "Lao"}, {"lt", "Lithuanian"},
+	{"lus", "Mizo"}, {"lv", "Latvian"}, {"mai", "Maithili"}, {"mg", "Malagasy"}, {"mi", "Maori"},
+	{"mk", "Macedonian"}, {"ml", "Malayalam"}, {"mn", "Mongolian"}, {"mni-Mtei", "Meiteilon (Manipuri)"}, {"mr", "Marathi"},
+	{"ms", "Malay"}, {"mt", "Maltese"}, {"my", "Myanmar (Burmese)"}, {"ne", "Nepali"}, {"nl", "Dutch"},
+	{"no", "Norwegian"}, {"nso", "Sepedi"}, {"ny", "Chichewa"}, {"om", "Oromo"}, {"or", "Odia (Oriya)"},
+	{"pa", "Punjabi"}, {"pl", "Polish"}, {"ps", "Pashto"}, {"pt", "Portuguese"}, {"qu", "Quechua"},
+	{"ro", "Romanian"}, {"ru", "Russian"}, {"rw", "Kinyarwanda"}, {"sa", "Sanskrit"}, {"sd", "Sindhi"},
+	{"si", "Sinhala"}, {"sk", "Slovak"}, {"sl", "Slovenian"}, {"sm", "Samoan"}, {"sn", "Shona"},
+	{"so", "Somali"}, {"sq", "Albanian"}, {"sr", "Serbian"}, {"st", "Sesotho"}, {"su", "Sundanese"},
+	{"sv", "Swedish"}, {"sw", "Swahili"}, {"ta", "Tamil"}, {"te", "Telugu"}, {"tg", "Tajik"},
+	{"th", "Thai"}, {"ti", "Tigrinya"}, {"tk", "Turkmen"}, {"tl", "Filipino"}, {"tr", "Turkish"},
+	{"ts", "Tsonga"}, {"tt", "Tatar"}, {"ug", "Uyghur"}, {"uk", "Ukrainian"}, {"ur", "Urdu"},
+	{"uz", "Uzbek"}, {"vi", "Vietnamese"}, {"xh", "Xhosa"}, {"yi", "Yiddish"}, {"yo", "Yoruba"},
+	{"zh-CN", "Chinese (Simplified)"}, {"zh-TW", "Chinese (Traditional)"}, {"zu", "Zulu"},
+}
+
+// Languages returns the languages of Google Translate; each one is translated in both directions
+// and "auto" lets Google detect the source.
+func (t *TranslationWebAPI) Languages() []entity.Language {
+	return googleLanguages()
+}
+
+// Languages returns the languages of Google Translate, which the offline provider stands in for:
+// texts missing from the dictionary are pseudo-localized whatever the direction.
+func (t *OfflineTranslationWebAPI) Languages() []entity.Language {
+	return googleLanguages()
+}
+
+func googleLanguages() []entity.Language {
+	languages := make([]entity.Language, 0, len(_googleLanguages)+1)
+	languages = append(languages, entity.Language{Code: entity.LanguageAuto, Name: "Detect language", Source: true})
+
+	for _, l := range _googleLanguages {
+		languages = append(languages, entity.Language{Code: l[0], Name: l[1], Source: true, Destination: true})
+	}
+
+	return languages
+}
@@ -0,0 +1,30 @@
+package webapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option -.
+type Option func(*TranslationWebAPI)
+
+// Timeout bounds each call; a shorter deadline of the caller's context still applies.
+func Timeout(timeout time.Duration) Option {
+	return func(t *TranslationWebAPI) {
+		t.timeout = timeout
+	}
+}
+
+// Client replaces the shared HTTP client, e.g. to use a custom transport.
+func Client(client *http.Client) Option {
+	return func(t *TranslationWebAPI) {
+		t.client = client
+	}
+}
+
+// URL replaces the translate endpoint.
+func URL(url string) Option {
+	return func(t *TranslationWebAPI) {
+		t.url = url
+	}
+}
@@ -0,0 +1,70 @@
+package resilient
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	_resultSuccess  = "success"
+	_resultFailure  = "failure"
+	_resultCanceled = "canceled"
+	_resultRejected = "rejected"
+)
+
+type metrics struct {
+	requests *prometheus.CounterVec
+	retries  prometheus.Counter
+	state    prometheus.Gauge
+}
+
+// newMetrics returns the metrics of provider; collectors shared by several providers are registered once.
+func newMetrics(reg prometheus.Registerer, provider string) *metrics {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "translation_provider_requests_total",
+		Help: "Translation provider calls by result: success, failure, canceled or rejected by the open circuit.",
+	}, []string{"provider", "result"})
+
+	retries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "translation_provider_retries_total",
+		Help: "Translation provider calls retried after a retryable error.",
+	}, []string{"provider"})
+
+	state := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "translation_provider_circuit_state",
+		Help: "Translation provider circuit breaker state: 0 closed, 1 half-open, 2 open.",
+	}, []string{"provider"})
+
+	if reg != nil {
+		requests = register(reg, requests)
+		retries = register(reg, retries)
+		state = register(reg, state)
+	}
+
+	m := &metrics{
+		requests: requests.MustCurryWith(prometheus.Labels{"provider": provider}),
+		retries:  retries.WithLabelValues(provider),
+		state:    state.WithLabelValues(provider),
+	}
+
+	m.state.Set(0)
+
+	return m
+}
+
+func register[T prometheus.Collector](reg prometheus.Registerer, collector T) T {
+	err := reg.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+
+	panic(err)
+}
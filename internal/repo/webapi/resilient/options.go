@@ -0,0 +1,40 @@
+package resilient
+
+import (
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/breaker"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option -.
+type Option func(*WebAPI)
+
+// Attempts sets the maximum number of provider calls per translation, 1 disables retries.
+func Attempts(attempts int) Option {
+	return func(w *WebAPI) {
+		w.attempts = max(attempts, 1)
+	}
+}
+
+// Backoff sets the delay before the first retry, doubled for every next retry up to maxBackoff.
+func Backoff(backoff, maxBackoff time.Duration) Option {
+	return func(w *WebAPI) {
+		w.backoff = backoff
+		w.maxBackoff = maxBackoff
+	}
+}
+
+// BreakerOptions configures the circuit breaker, e.g. breaker.FailureThreshold.
+func BreakerOptions(opts ...breaker.Option) Option {
+	return func(w *WebAPI) {
+		w.breakerOptions = append(w.breakerOptions, opts...)
+	}
+}
+
+// Metrics registers the provider request, retry and circuit state metrics.
+func Metrics(reg prometheus.Registerer) Option {
+	return func(w *WebAPI) {
+		w.registerer = reg
+	}
+}
@@ -0,0 +1,222 @@
+// Package resilient decorates a repo.TranslationWebAPI with retries and a circuit breaker.
+package resilient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/evrone/go-clean-template/pkg/breaker"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	_defaultAttempts   = 3
+	_defaultBackoff    = 100 * time.Millisecond
+	_defaultMaxBackoff = time.Second
+)
+
+// WebAPI retries retryable provider errors with exponential backoff and jitter, and stops calling
+// the provider while its circuit breaker is open, returning entity.ErrProviderUnavailable instead.
+type WebAPI struct {
+	next     repo.TranslationWebAPI
+	provider string
+	breaker  *breaker.Breaker
+	metrics  *metrics
+
+	attempts       int
+	backoff        time.Duration
+	maxBackoff     time.Duration
+	breakerOptions []breaker.Option
+	registerer     prometheus.Registerer
+
+	logger logger.Interface
+}
+
+// New -.
+func New(next repo.TranslationWebAPI, provider string, l logger.Interface, opts ...Option) *WebAPI {
+	w := &WebAPI{
+		next:       next,
+		provider:   provider,
+		attempts:   _defaultAttempts,
+		backoff:    _defaultBackoff,
+		maxBackoff: _defaultMaxBackoff,
+		logger:     l,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.metrics = newMetrics(w.registerer, provider)
+	w.breaker = breaker.New(provider, append(w.breakerOptions, breaker.OnStateChange(w.stateChanged))...)
+
+	return w
+}
+
+// Name returns the provider name.
+func (w *WebAPI) Name() string {
+	return w.provider
+}
+
+// Status returns the circuit breaker state and false while it is open.
+func (w *WebAPI) Status() (string, bool) {
+	state := w.breaker.State()
+
+	return state.String(), state != breaker.StateOpen
+}
+
+// Translate -.
+func (w *WebAPI) Translate(ctx context.Context, translation entity.Translation) (entity.Translation, error) {
+	return call(ctx, w, "Translate", func() (entity.Translation, error) {
+		return w.next.Translate(ctx, translation) //nolint:wrapcheck // wrapped by call
+	})
+}
+
+// DetectLanguage -.
+func (w *WebAPI) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	return call(ctx, w, "DetectLanguage", func() (entity.LanguageDetection, error) {
+		return w.next.DetectLanguage(ctx, text) //nolint:wrapcheck // wrapped by call
+	})
+}
+
+// call runs fn, a call to the method of the provider, through the breaker with retries.
+func call[T any](ctx context.Context, w *WebAPI, method string, fn func() (T, error)) (T, error) {
+	var (
+		zero T
+		err  error
+	)
+
+	for attempt := range w.attempts {
+		if attempt > 0 {
+			w.metrics.retries.Inc()
+
+			err = w.wait(ctx, attempt)
+			if err != nil {
+				return zero, fmt.Errorf("resilient - WebAPI - %s - w.wait: %w", method, err)
+			}
+		}
+
+		err = w.breaker.Allow()
+		if err != nil {
+			w.metrics.requests.WithLabelValues(_resultRejected).Inc()
+
+			return zero, w.unavailable(method, err)
+		}
+
+		var result T
+
+		result, err = fn()
+		w.report(ctx, err)
+
+		if err == nil {
+			return result, nil
+		}
+
+		if ctx.Err() != nil || !retryable(err) {
+			break
+		}
+	}
+
+	return zero, fmt.Errorf("resilient - WebAPI - %s - w.next.%s: %w", method, method, err)
+}
+
+// report records the outcome in the breaker: only provider faults count as failures.
+func (w *WebAPI) report(ctx context.Context, err error) {
+	switch {
+	case err == nil:
+		w.metrics.requests.WithLabelValues(_resultSuccess).Inc()
+		w.breaker.Success()
+	case ctx.Err() != nil:
+		w.metrics.requests.WithLabelValues(_resultCanceled).Inc()
+		w.breaker.Release()
+	case clientError(err):
+		w.metrics.requests.WithLabelValues(_resultFailure).Inc()
+		w.breaker.Success()
+	default:
+		w.metrics.requests.WithLabelValues(_resultFailure).Inc()
+		w.breaker.Failure()
+	}
+}
+
+func (w *WebAPI) unavailable(method string, err error) error {
+	retryAfter := int((w.breaker.RetryAfter() + time.Second - 1) / time.Second) // round up
+
+	appErr := entity.ErrProviderUnavailable.WithDetails(map[string]string{
+		"provider":              w.provider,
+		entity.DetailRetryAfter: strconv.Itoa(max(retryAfter, 1)),
+	})
+
+	return entity.NewAppError(appErr, fmt.Errorf("resilient - WebAPI - %s - w.breaker.Allow: %w", method, err))
+}
+
+// wait sleeps backoff * 2^(attempt-1), capped by maxBackoff, with equal jitter.
+func (w *WebAPI) wait(ctx context.Context, attempt int) error {
+	delay := w.backoff
+
+	for range attempt - 1 {
+		if delay >= w.maxBackoff {
+			break
+		}
+
+		delay *= 2
+	}
+
+	delay = min(delay, w.maxBackoff)
+	half := delay / 2 //nolint:mnd // equal jitter
+
+	timer := time.NewTimer(half + rand.N(half+1)) //nolint:gosec // jitter does not need a secure source
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (w *WebAPI) stateChanged(provider string, from, to breaker.State) {
+	w.metrics.state.Set(float64(to))
+
+	if to == breaker.StateOpen {
+		w.logger.Warn("resilient - WebAPI - provider %s circuit %s -> %s", provider, from, to)
+
+		return
+	}
+
+	w.logger.Info("resilient - WebAPI - provider %s circuit %s -> %s", provider, from, to)
+}
+
+// retryable reports whether another attempt may succeed: network errors, timeouts, 429 and 5xx responses.
+func retryable(err error) bool {
+	var statusErr *webapi.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// clientError reports a rejected request, which does not mean the provider is down.
+func clientError(err error) bool {
+	var statusErr *webapi.StatusError
+
+	return errors.As(err, &statusErr) &&
+		statusErr.StatusCode >= http.StatusBadRequest &&
+		statusErr.StatusCode < http.StatusInternalServerError &&
+		statusErr.StatusCode != http.StatusTooManyRequests
+}
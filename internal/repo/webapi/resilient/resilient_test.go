@@ -0,0 +1,173 @@
+package resilient_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/evrone/go-clean-template/internal/repo/webapi/resilient"
+	"github.com/evrone/go-clean-template/pkg/breaker"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnknown = errors.New("unknown")
+
+// provider returns the errors in order, then succeeds.
+type provider struct {
+	calls  atomic.Int64
+	errors []error
+}
+
+func (p *provider) Translate(_ context.Context, t entity.Translation) (entity.Translation, error) {
+	call := int(p.calls.Add(1)) - 1
+	if call < len(p.errors) && p.errors[call] != nil {
+		return entity.Translation{}, p.errors[call]
+	}
+
+	t.Translation = "text"
+
+	return t, nil
+}
+
+func (p *provider) DetectLanguage(_ context.Context, _ string) (entity.LanguageDetection, error) {
+	call := int(p.calls.Add(1)) - 1
+	if call < len(p.errors) && p.errors[call] != nil {
+		return entity.LanguageDetection{}, p.errors[call]
+	}
+
+	return entity.LanguageDetection{Language: "en", Confidence: 1}, nil
+}
+
+func status(code int) error {
+	return &webapi.StatusError{StatusCode: code, Err: errors.New("bad status")}
+}
+
+func newWebAPI(p *provider, opts ...resilient.Option) *resilient.WebAPI {
+	return resilient.New(p, "google", logger.New("error"),
+		append([]resilient.Option{resilient.Backoff(time.Millisecond, 2*time.Millisecond)}, opts...)...)
+}
+
+func TestWebAPI_RetriesRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	p := &provider{errors: []error{status(503), status(429)}}
+
+	result, err := newWebAPI(p, resilient.Metrics(reg)).Translate(t.Context(), entity.Translation{Original: "текст"})
+	require.NoError(t, err)
+	require.Equal(t, "text", result.Translation)
+	require.EqualValues(t, 3, p.calls.Load())
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP translation_provider_retries_total Translation provider calls retried after a retryable error.
+# TYPE translation_provider_retries_total counter
+translation_provider_retries_total{provider="google"} 2
+`), "translation_provider_retries_total"))
+}
+
+func TestWebAPI_DetectLanguageRetries(t *testing.T) {
+	t.Parallel()
+
+	p := &provider{errors: []error{status(503)}}
+
+	detection, err := newWebAPI(p).DetectLanguage(t.Context(), "text")
+	require.NoError(t, err)
+	require.Equal(t, "en", detection.Language)
+	require.EqualValues(t, 2, p.calls.Load())
+}
+
+func TestWebAPI_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range []error{status(400), errUnknown} {
+		p := &provider{errors: []error{err}}
+		w := newWebAPI(p, resilient.BreakerOptions(breaker.FailureThreshold(1)))
+
+		_, got := w.Translate(t.Context(), entity.Translation{})
+		require.ErrorIs(t, got, err)
+		require.EqualValues(t, 1, p.calls.Load())
+	}
+
+	// A rejected request does not open the circuit.
+	w := newWebAPI(&provider{errors: []error{status(400)}}, resilient.BreakerOptions(breaker.FailureThreshold(1)))
+
+	_, err := w.Translate(t.Context(), entity.Translation{})
+	require.Error(t, err)
+
+	state, healthy := w.Status()
+	require.Equal(t, "closed", state)
+	require.True(t, healthy)
+}
+
+func TestWebAPI_OpenCircuitReturnsProviderUnavailable(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	p := &provider{errors: []error{status(502), status(502), status(502)}}
+	w := newWebAPI(p,
+		resilient.Attempts(2),
+		resilient.Metrics(reg),
+		resilient.BreakerOptions(breaker.FailureThreshold(2), breaker.OpenTimeout(50*time.Millisecond)),
+	)
+
+	_, err := w.Translate(t.Context(), entity.Translation{})
+	require.NotErrorIs(t, err, entity.ErrProviderUnavailable)
+
+	var statusErr *webapi.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.EqualValues(t, 2, p.calls.Load())
+
+	_, err = w.Translate(t.Context(), entity.Translation{})
+	require.ErrorIs(t, err, entity.ErrProviderUnavailable)
+	require.ErrorIs(t, err, breaker.ErrOpen)
+	require.Equal(t, "1", entity.GetAppError(err).Details[entity.DetailRetryAfter])
+	require.Equal(t, "google", entity.GetAppError(err).Details["provider"])
+	require.EqualValues(t, 2, p.calls.Load())
+
+	state, healthy := w.Status()
+	require.Equal(t, "open", state)
+	require.False(t, healthy)
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP translation_provider_circuit_state Translation provider circuit breaker state: 0 closed, 1 half-open, 2 open.
+# TYPE translation_provider_circuit_state gauge
+translation_provider_circuit_state{provider="google"} 2
+`), "translation_provider_circuit_state"))
+
+	// After the open timeout a successful probe closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+
+	p.errors = nil
+	p.calls.Store(0)
+
+	_, err = w.Translate(t.Context(), entity.Translation{})
+	require.NoError(t, err)
+
+	state, _ = w.Status()
+	require.Equal(t, "closed", state)
+}
+
+func TestWebAPI_StopsOnCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	p := &provider{errors: []error{status(503)}}
+
+	w := resilient.New(p, "google", logger.New("error"), resilient.Backoff(time.Hour, time.Hour))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := w.Translate(ctx, entity.Translation{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 1, p.calls.Load())
+}
@@ -3,38 +3,191 @@ package webapi
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
-	translator "github.com/Conight/go-googletrans"
 	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/goccy/go-json"
 )
 
-// TranslationWebAPI -.
+const (
+	_defaultURL       = "https://translate.google.com/translate_a/single"
+	_defaultUserAgent = "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:15.0) Gecko/20100101 Firefox/15.0.1"
+	_defaultTimeout   = 5 * time.Second
+
+	_maxResponseSize = 1 << 20
+
+	// _detectDestination is the destination of the translation made to detect the language, which is discarded.
+	_detectDestination = "en"
+)
+
+// StatusError is returned when the provider answers with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+// Error -.
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap -.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// TranslationWebAPI calls the public Google Translate endpoint. The HTTP client is shared by all calls,
+// so connections are reused; each call is bounded by its context and by the timeout, whichever ends first.
 type TranslationWebAPI struct {
-	conf translator.Config
+	client    *http.Client
+	url       string
+	userAgent string
+	timeout   time.Duration
 }
 
 // New -.
-func New() *TranslationWebAPI {
-	conf := translator.Config{
-		UserAgent:   []string{"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:15.0) Gecko/20100101 Firefox/15.0.1"},
-		ServiceUrls: []string{"translate.google.com"},
+func New(opts ...Option) *TranslationWebAPI {
+	t := &TranslationWebAPI{
+		url:       _defaultURL,
+		userAgent: _defaultUserAgent,
+		timeout:   _defaultTimeout,
 	}
 
-	return &TranslationWebAPI{
-		conf: conf,
+	// Custom options
+	for _, opt := range opts {
+		opt(t)
 	}
+
+	if t.client == nil {
+		t.client = &http.Client{Transport: newTransport()}
+	}
+
+	return t
 }
 
-// Translate -.
-func (t *TranslationWebAPI) Translate(_ context.Context, translation entity.Translation) (entity.Translation, error) {
-	trans := translator.New(t.conf)
+type response struct {
+	Sentences []struct {
+		Trans string `json:"trans"`
+	} `json:"sentences"`
+	Src      string `json:"src"`
+	LDResult struct {
+		SrcLangsConfidences []float64 `json:"srclangs_confidences"`
+	} `json:"ld_result"`
+}
 
-	result, err := trans.Translate(translation.Original, translation.Source, translation.Destination)
+// Translate -.
+// With the "auto" source, the language detected by Google is set on the translation.
+func (t *TranslationWebAPI) Translate(ctx context.Context, translation entity.Translation) (entity.Translation, error) {
+	result, err := t.call(ctx, translation.Source, translation.Destination, translation.Original)
 	if err != nil {
-		return entity.Translation{}, fmt.Errorf("TranslationWebAPI - Translate - trans.Translate: %w", err)
+		return entity.Translation{}, fmt.Errorf("TranslationWebAPI - Translate - t.call: %w", err)
+	}
+
+	var text strings.Builder
+	for _, sentence := range result.Sentences {
+		text.WriteString(sentence.Trans)
 	}
 
-	translation.Translation = result.Text
+	translation.Translation = text.String()
+
+	if strings.EqualFold(translation.Source, _sourceAuto) {
+		detection := result.detection()
+		translation.DetectedLanguage, translation.DetectionConfidence = detection.Language, detection.Confidence
+	}
 
 	return translation, nil
 }
+
+// DetectLanguage translates the text from "auto" and returns the source language detected by Google.
+func (t *TranslationWebAPI) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	result, err := t.call(ctx, _sourceAuto, _detectDestination, text)
+	if err != nil {
+		return entity.LanguageDetection{}, fmt.Errorf("TranslationWebAPI - DetectLanguage - t.call: %w", err)
+	}
+
+	return result.detection(), nil
+}
+
+func (t *TranslationWebAPI) call(ctx context.Context, source, destination, text string) (response, error) {
+	// The caller's deadline wins when it is earlier than the timeout.
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	// The text goes in the body: percent-encoded in the URL, a chunk of non-ASCII text exceeds the URL limit.
+	form := url.Values{"q": {text}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, strings.NewReader(form))
+	if err != nil {
+		return response{}, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	req.URL.RawQuery = url.Values{
+		"client": {"gtx"},
+		"sl":     {strings.ToLower(source)},
+		"tl":     {strings.ToLower(destination)},
+		"hl":     {strings.ToLower(destination)},
+		"dt":     {"t"},
+		"dj":     {"1"},
+	}.Encode()
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", t.userAgent)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return response{}, fmt.Errorf("t.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return response{}, &StatusError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("expected status code 200, got: %d", resp.StatusCode),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, _maxResponseSize))
+	if err != nil {
+		return response{}, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	var result response
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return response{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r response) detection() entity.LanguageDetection {
+	detection := entity.LanguageDetection{Language: r.Src}
+	if len(r.LDResult.SrcLangsConfidences) > 0 {
+		detection.Confidence = r.LDResult.SrcLangsConfidences[0]
+	}
+
+	return detection
+}
+
+func newTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   _defaultTimeout,
+		KeepAlive: 30 * time.Second, //nolint:mnd // default of http.DefaultTransport
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,              //nolint:mnd // default of http.DefaultTransport
+		MaxIdleConnsPerHost:   16,               //nolint:mnd // all calls go to the same host
+		IdleConnTimeout:       90 * time.Second, //nolint:mnd // default of http.DefaultTransport
+		TLSHandshakeTimeout:   _defaultTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
@@ -0,0 +1,50 @@
+package webapi_test
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/evrone/go-clean-template/internal/repo/webapi/cassette"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTranslationWebAPI_Cassette replays testdata/cassettes/google.json, which only holds recorded interactions:
+// provider errors are tested with httptest servers. Re-record with the real provider: CASSETTE_MODE=record go test ./internal/repo/webapi -run Cassette.
+func TestTranslationWebAPI_Cassette(t *testing.T) {
+	t.Parallel()
+
+	recorder := cassette.New(t, "testdata/cassettes/google.json", cassette.ModeFromEnv(), nil)
+	translationWebAPI := webapi.New(webapi.Client(recorder.Client()))
+
+	tests := []struct {
+		name        string
+		translation entity.Translation
+		expected    string
+		detected    entity.LanguageDetection
+	}{
+		{
+			name:        "single sentence",
+			translation: entity.Translation{Source: "ru", Destination: "en", Original: "текст для перевода"},
+			expected:    "text for translation",
+		},
+		{
+			name:        "several sentences keep line breaks",
+			translation: entity.Translation{Source: "auto", Destination: "vi", Original: "Hello, world!\nHow are you?"},
+			expected:    "Chào thế giới!\nBạn có khỏe không?",
+			detected:    entity.LanguageDetection{Language: "en", Confidence: 0.98046875},
+		},
+	}
+
+	// Subtests are sequential: the cassette is shared.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation, err := translationWebAPI.Translate(t.Context(), tt.translation)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, translation.Translation)
+			require.Equal(t, tt.detected.Language, translation.DetectedLanguage)
+			require.InDelta(t, tt.detected.Confidence, translation.DetectionConfidence, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package webapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationWebAPI_Translate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The text is sent in the form body, not in the URL.
+		if r.Method != http.MethodPost || r.URL.Query().Has("q") ||
+			r.FormValue("sl") != "en" || r.FormValue("tl") != "vi" || r.PostFormValue("q") != "Hello. World." {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"sentences":[{"trans":"Xin chào. "},{"trans":"Thế giới."}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	translation, err := webapi.New(webapi.URL(server.URL)).Translate(t.Context(), entity.Translation{
+		Source:      "EN",
+		Destination: "vi",
+		Original:    "Hello. World.",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Xin chào. Thế giới.", translation.Translation)
+}
+
+func TestTranslationWebAPI_DetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("sl") != "auto" || r.PostFormValue("q") != "Bonjour le monde" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"sentences":[{"trans":"Hello world"}],"src":"fr","ld_result":{"srclangs":["fr"],"srclangs_confidences":[0.93]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	detection, err := webapi.New(webapi.URL(server.URL)).DetectLanguage(t.Context(), "Bonjour le monde")
+	require.NoError(t, err)
+	require.Equal(t, entity.LanguageDetection{Language: "fr", Confidence: 0.93}, detection)
+}
+
+func TestTranslationWebAPI_StatusError(t *testing.T) {
+	t.Parallel()
+
+	// The page Google answers to rate limited clients, it cannot be recorded on demand.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("<html><head><title>Sorry...</title></head><body>We're sorry... " +
+			"but your computer or network may be sending automated queries.</body></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := webapi.New(webapi.URL(server.URL)).Translate(t.Context(), entity.Translation{})
+
+	var statusErr *webapi.StatusError
+
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+}
+
+func TestTranslationWebAPI_Deadline(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+	}{
+		{
+			name:    "timeout",
+			timeout: 50 * time.Millisecond,
+			ctx:     func() (context.Context, context.CancelFunc) { return t.Context(), func() {} },
+		},
+		{
+			name:    "caller deadline",
+			timeout: time.Minute,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(t.Context(), 50*time.Millisecond)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+
+			_, err := webapi.New(webapi.URL(server.URL), webapi.Timeout(tt.timeout)).Translate(ctx, entity.Translation{})
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Less(t, time.Since(start), 5*time.Second)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/goccy/go-json"
+)
+
+const _sourceAuto = "auto"
+
+// _pseudo replaces Latin letters with accented lookalikes, so untranslated text is easy to spot.
+var _pseudo = strings.NewReplacer( //nolint:gochecknoglobals // read-only table
+	"a", "ą", "b", "ƀ", "c", "ç", "d", "đ", "e", "ę", "f", "ƒ", "g", "ğ", "h", "ħ", "i", "į",
+	"j", "ĵ", "k", "ķ", "l", "ł", "m", "ɱ", "n", "ñ", "o", "ø", "p", "þ", "q", "ʠ", "r", "ŕ",
+	"s", "š", "t", "ŧ", "u", "ų", "v", "ṽ", "w", "ŵ", "x", "x", "y", "ÿ", "z", "ž",
+	"A", "Ą", "B", "Ɓ", "C", "Ç", "D", "Đ", "E", "Ę", "F", "Ƒ", "G", "Ğ", "H", "Ħ", "I", "Į",
+	"J", "Ĵ", "K", "Ķ", "L", "Ł", "M", "Ṁ", "N", "Ñ", "O", "Ø", "P", "Þ", "Q", "Ǫ", "R", "Ŕ",
+	"S", "Š", "T", "Ŧ", "U", "Ų", "V", "Ṽ", "W", "Ŵ", "X", "Ẋ", "Y", "Ÿ", "Z", "Ž",
+)
+
+type dictionaryKey struct {
+	source      string
+	destination string
+	original    string
+}
+
+// OfflineTranslationWebAPI translates from a dictionary without network calls, for development and tests.
+// Texts missing from the dictionary are pseudo-localized: "text" becomes "[!! ŧęxŧ !!]".
+// Languages are detected by an NGramDetector.
+type OfflineTranslationWebAPI struct {
+	dictionary map[dictionaryKey]string
+	detector   *NGramDetector
+}
+
+// NewOffline loads the dictionary, a JSON array of translations:
+//
+//	[{"source": "ru", "destination": "en", "original": "текст", "translation": "text"}]
+//
+// An empty path leaves the dictionary empty.
+func NewOffline(path string) (*OfflineTranslationWebAPI, error) {
+	t := &OfflineTranslationWebAPI{dictionary: make(map[dictionaryKey]string), detector: NewNGramDetector()}
+
+	if path == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("OfflineTranslationWebAPI - NewOffline - os.ReadFile: %w", err)
+	}
+
+	var entries []entity.Translation
+
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("OfflineTranslationWebAPI - NewOffline - json.Unmarshal(%s): %w", path, err)
+	}
+
+	for _, e := range entries {
+		source, destination := strings.ToLower(e.Source), strings.ToLower(e.Destination)
+
+		t.dictionary[dictionaryKey{source, destination, e.Original}] = e.Translation
+
+		// With source "auto" the first entry for the destination wins.
+		auto := dictionaryKey{_sourceAuto, destination, e.Original}
+		if _, ok := t.dictionary[auto]; !ok {
+			t.dictionary[auto] = e.Translation
+		}
+	}
+
+	return t, nil
+}
+
+// Translate -.
+func (t *OfflineTranslationWebAPI) Translate(ctx context.Context, translation entity.Translation) (entity.Translation, error) {
+	if err := ctx.Err(); err != nil {
+		return entity.Translation{}, fmt.Errorf("OfflineTranslationWebAPI - Translate: %w", err)
+	}
+
+	key := dictionaryKey{
+		source:      strings.ToLower(translation.Source),
+		destination: strings.ToLower(translation.Destination),
+		original:    translation.Original,
+	}
+
+	text, ok := t.dictionary[key]
+	if !ok {
+		text = Pseudolocalize(translation.Original)
+	}
+
+	translation.Translation = text
+
+	if key.source == _sourceAuto {
+		detection, err := t.detector.DetectLanguage(ctx, translation.Original)
+		if err == nil {
+			translation.DetectedLanguage, translation.DetectionConfidence = detection.Language, detection.Confidence
+		}
+	}
+
+	return translation, nil
+}
+
+// DetectLanguage -.
+func (t *OfflineTranslationWebAPI) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	detection, err := t.detector.DetectLanguage(ctx, text)
+	if err != nil {
+		return entity.LanguageDetection{}, fmt.Errorf("OfflineTranslationWebAPI - DetectLanguage: %w", err)
+	}
+
+	return detection, nil
+}
+
+// Pseudolocalize returns the text with accented Latin letters, wrapped in "[!! " and " !!]".
+func Pseudolocalize(text string) string {
+	return "[!! " + _pseudo.Replace(text) + " !!]"
+}
@@ -0,0 +1,80 @@
+package webapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo/webapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineTranslationWebAPI_Translate(t *testing.T) {
+	t.Parallel()
+
+	offline, err := webapi.NewOffline("testdata/dictionary.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		translation entity.Translation
+		expected    string
+		detected    string
+	}{
+		{
+			name:        "dictionary",
+			translation: entity.Translation{Source: "RU", Destination: "en", Original: "текст для перевода"},
+			expected:    "text for translation",
+		},
+		{
+			name:        "auto source uses the first entry",
+			translation: entity.Translation{Source: "auto", Destination: "en", Original: "текст для перевода"},
+			expected:    "text for translation",
+			detected:    "ru",
+		},
+		{
+			name:        "pseudo-localization",
+			translation: entity.Translation{Source: "en", Destination: "de", Original: "text 42"},
+			expected:    "[!! ŧęxŧ 42 !!]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			translation, err := offline.Translate(t.Context(), tt.translation)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, translation.Translation)
+			require.Equal(t, tt.translation.Original, translation.Original)
+			require.Equal(t, tt.detected, translation.DetectedLanguage)
+		})
+	}
+}
+
+func TestOfflineTranslationWebAPI_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := webapi.NewOffline("testdata/missing.json")
+	require.Error(t, err)
+
+	offline, err := webapi.NewOffline("")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = offline.Translate(ctx, entity.Translation{Original: "text"})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOfflineTranslationWebAPI_Languages(t *testing.T) {
+	t.Parallel()
+
+	offline, err := webapi.NewOffline("")
+	require.NoError(t, err)
+
+	languages := offline.Languages()
+	require.Equal(t, entity.Language{Code: "auto", Name: "Detect language", Source: true}, languages[0])
+	require.Contains(t, languages, entity.Language{Code: "vi", Name: "Vietnamese", Source: true, Destination: true})
+}
@@ -13,6 +13,21 @@ type (
 	// Translation -.
 	Translation interface {
 		Translate(context.Context, entity.Translation) (entity.Translation, error)
+		TranslateFile(context.Context, entity.TranslationFile) (entity.TranslationFile, error)
 		History(context.Context) (entity.TranslationHistory, error)
+		Languages(context.Context) (entity.LanguageList, error)
+		DetectLanguage(context.Context, string) (entity.LanguageDetection, error)
+	}
+
+	// Glossary -.
+	Glossary interface {
+		Glossaries(context.Context) (entity.GlossaryList, error)
+		Glossary(ctx context.Context, id int64) (entity.Glossary, error)
+		CreateGlossary(context.Context, entity.Glossary) (entity.Glossary, error)
+		UpdateGlossary(context.Context, entity.Glossary) (entity.Glossary, error)
+		DeleteGlossary(ctx context.Context, id int64) error
+		StoreEntries(ctx context.Context, glossaryID int64, entries []entity.GlossaryEntry) ([]entity.GlossaryEntry, error)
+		DeleteEntry(ctx context.Context, glossaryID, entryID int64) error
+		ImportGlossary(context.Context, entity.GlossaryImport) (entity.Glossary, error)
 	}
 )
@@ -0,0 +1,322 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo"
+)
+
+// _maxEntryLength is the length of the term and translation columns of glossary entries.
+const _maxEntryLength = 255
+
+// UseCase manages the glossaries enforced by the translation use case.
+type UseCase struct {
+	repo      repo.GlossaryRepo
+	languages []entity.Language
+}
+
+// New -.
+func New(r repo.GlossaryRepo, opts ...Option) *UseCase {
+	uc := &UseCase{repo: r}
+
+	for _, opt := range opts {
+		opt(uc)
+	}
+
+	return uc
+}
+
+// Glossaries returns the glossaries without their entries.
+func (uc *UseCase) Glossaries(ctx context.Context) (entity.GlossaryList, error) {
+	glossaries, err := uc.repo.GetGlossaries(ctx)
+	if err != nil {
+		return entity.GlossaryList{}, repoError(fmt.Errorf("GlossaryUseCase - Glossaries - s.repo.GetGlossaries: %w", err))
+	}
+
+	return entity.GlossaryList{Glossaries: glossaries}, nil
+}
+
+// Glossary returns the glossary with its entries.
+func (uc *UseCase) Glossary(ctx context.Context, id int64) (entity.Glossary, error) {
+	g, err := uc.repo.GetGlossary(ctx, id)
+	if err != nil {
+		return entity.Glossary{}, repoError(fmt.Errorf("GlossaryUseCase - Glossary - s.repo.GetGlossary: %w", err))
+	}
+
+	return g, nil
+}
+
+// CreateGlossary stores the glossary and its entries, if any.
+func (uc *UseCase) CreateGlossary(ctx context.Context, g entity.Glossary) (entity.Glossary, error) {
+	g, err := uc.checkGlossary("GlossaryUseCase - CreateGlossary", g)
+	if err != nil {
+		return entity.Glossary{}, err
+	}
+
+	entries, err := checkEntries("GlossaryUseCase - CreateGlossary", g.Entries)
+	if err != nil {
+		return entity.Glossary{}, err
+	}
+
+	g.Entries = entries
+
+	created, err := uc.repo.CreateGlossary(ctx, g)
+	if err != nil {
+		return entity.Glossary{}, repoError(fmt.Errorf("GlossaryUseCase - CreateGlossary - s.repo.CreateGlossary: %w", err))
+	}
+
+	return created, nil
+}
+
+// UpdateGlossary sets the name and the languages of the glossary, its entries are kept.
+func (uc *UseCase) UpdateGlossary(ctx context.Context, g entity.Glossary) (entity.Glossary, error) {
+	g, err := uc.checkGlossary("GlossaryUseCase - UpdateGlossary", g)
+	if err != nil {
+		return entity.Glossary{}, err
+	}
+
+	g.Entries = nil
+
+	updated, err := uc.repo.UpdateGlossary(ctx, g)
+	if err != nil {
+		return entity.Glossary{}, repoError(fmt.Errorf("GlossaryUseCase - UpdateGlossary - s.repo.UpdateGlossary: %w", err))
+	}
+
+	return updated, nil
+}
+
+// DeleteGlossary deletes the glossary and its entries.
+func (uc *UseCase) DeleteGlossary(ctx context.Context, id int64) error {
+	err := uc.repo.DeleteGlossary(ctx, id)
+	if err != nil {
+		return repoError(fmt.Errorf("GlossaryUseCase - DeleteGlossary - s.repo.DeleteGlossary: %w", err))
+	}
+
+	return nil
+}
+
+// StoreEntries adds entries to the glossary; an entry with the term of an existing one replaces it.
+func (uc *UseCase) StoreEntries(
+	ctx context.Context,
+	glossaryID int64,
+	entries []entity.GlossaryEntry,
+) ([]entity.GlossaryEntry, error) {
+	entries, err := checkEntries("GlossaryUseCase - StoreEntries", entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "entries", "reason": "no entries"}),
+			errors.New("GlossaryUseCase - StoreEntries: no entries"),
+		)
+	}
+
+	stored, err := uc.repo.StoreEntries(ctx, glossaryID, entries)
+	if err != nil {
+		return nil, repoError(fmt.Errorf("GlossaryUseCase - StoreEntries - s.repo.StoreEntries: %w", err))
+	}
+
+	return stored, nil
+}
+
+// DeleteEntry deletes an entry of the glossary.
+func (uc *UseCase) DeleteEntry(ctx context.Context, glossaryID, entryID int64) error {
+	err := uc.repo.DeleteEntry(ctx, glossaryID, entryID)
+	if err != nil {
+		return repoError(fmt.Errorf("GlossaryUseCase - DeleteEntry - s.repo.DeleteEntry: %w", err))
+	}
+
+	return nil
+}
+
+// ImportGlossary adds the terms of a CSV or TBX file to the glossary, as StoreEntries does,
+// and returns the glossary with all its entries. Files are read in the languages of the glossary.
+func (uc *UseCase) ImportGlossary(ctx context.Context, file entity.GlossaryImport) (entity.Glossary, error) {
+	g, err := uc.repo.GetGlossary(ctx, file.GlossaryID)
+	if err != nil {
+		return entity.Glossary{}, repoError(fmt.Errorf("GlossaryUseCase - ImportGlossary - s.repo.GetGlossary: %w", err))
+	}
+
+	var entries []entity.GlossaryEntry
+
+	switch file.Format {
+	case entity.GlossaryFormatCSV:
+		entries, err = parseCSV(file.Content, g.Source, g.Destination)
+	case entity.GlossaryFormatTBX:
+		entries, err = parseTBX(file.Content, g.Source, g.Destination)
+	default:
+		return entity.Glossary{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "format"}),
+			fmt.Errorf("GlossaryUseCase - ImportGlossary: unknown format %q", file.Format),
+		)
+	}
+
+	if err != nil {
+		details := map[string]string{"field": "file", "reason": err.Error()}
+
+		var lineErr *lineError
+		if errors.As(err, &lineErr) {
+			details["line"] = strconv.Itoa(lineErr.line)
+		}
+
+		return entity.Glossary{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(details),
+			fmt.Errorf("GlossaryUseCase - ImportGlossary - parse: %w", err),
+		)
+	}
+
+	_, err = uc.StoreEntries(ctx, g.ID, entries)
+	if err != nil {
+		return entity.Glossary{}, fmt.Errorf("GlossaryUseCase - ImportGlossary: %w", err)
+	}
+
+	return uc.Glossary(ctx, g.ID)
+}
+
+// checkGlossary trims the name and the languages of the glossary and rejects empty ones,
+// an auto source, which is never translated with a glossary, and languages the provider does not translate.
+func (uc *UseCase) checkGlossary(method string, g entity.Glossary) (entity.Glossary, error) {
+	g.Name = strings.TrimSpace(g.Name)
+	g.Source = strings.TrimSpace(g.Source)
+	g.Destination = strings.TrimSpace(g.Destination)
+
+	for _, field := range [][2]string{{"name", g.Name}, {"source", g.Source}, {"destination", g.Destination}} {
+		if field[1] == "" {
+			return entity.Glossary{}, entity.NewAppError(
+				entity.ErrValidation.WithDetails(map[string]string{"field": field[0]}),
+				fmt.Errorf("%s: empty %s", method, field[0]),
+			)
+		}
+	}
+
+	if strings.EqualFold(g.Source, entity.LanguageAuto) {
+		return entity.Glossary{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "source", "reason": "a glossary needs a source language"}),
+			fmt.Errorf("%s: auto source", method),
+		)
+	}
+
+	err := uc.checkLanguages(method, g.Source, g.Destination)
+	if err != nil {
+		return entity.Glossary{}, err
+	}
+
+	return g, nil
+}
+
+// checkLanguages rejects a source or destination missing from the languages, or not supported
+// in that direction, with entity.ErrValidation naming the field, as the translation use case does.
+func (uc *UseCase) checkLanguages(method, source, destination string) error {
+	if len(uc.languages) == 0 {
+		return nil
+	}
+
+	fields := []struct {
+		name, code string
+		supported  func(entity.Language) bool
+	}{
+		{"source", source, func(l entity.Language) bool { return l.Source }},
+		{"destination", destination, func(l entity.Language) bool { return l.Destination }},
+	}
+
+	for _, field := range fields {
+		l, ok := uc.lookup(field.code)
+		if ok && field.supported(l) {
+			continue
+		}
+
+		reason := "unsupported language"
+		if ok {
+			reason = "unsupported as " + field.name
+		}
+
+		return entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": field.name, "language": field.code, "reason": reason}),
+			fmt.Errorf("%s: %s %q: %s", method, field.name, field.code, reason),
+		)
+	}
+
+	return nil
+}
+
+// lookup finds the language of a BCP-47 tag, ignoring case. A tag missing from the languages
+// matches its language subtag, so en-US is checked as en.
+func (uc *UseCase) lookup(code string) (entity.Language, bool) {
+	base, _, _ := strings.Cut(code, "-")
+
+	for _, candidate := range []string{code, base} {
+		i := slices.IndexFunc(uc.languages, func(l entity.Language) bool { return strings.EqualFold(l.Code, candidate) })
+		if i >= 0 {
+			return uc.languages[i], true
+		}
+	}
+
+	return entity.Language{}, false
+}
+
+// checkEntries trims the terms and translations of entries and rejects empty terms, missing translations,
+// terms or translations longer than the columns storing them and duplicate terms. Translations of do-not-translate entries are dropped.
+func checkEntries(method string, entries []entity.GlossaryEntry) ([]entity.GlossaryEntry, error) {
+	checked := make([]entity.GlossaryEntry, 0, len(entries))
+	terms := make(map[string]bool, len(entries))
+
+	for i, e := range entries {
+		e.Term = strings.Join(strings.Fields(e.Term), " ")
+		e.Translation = strings.TrimSpace(e.Translation)
+
+		if e.DoNotTranslate {
+			e.Translation = ""
+		}
+
+		var reason string
+
+		switch {
+		case e.Term == "":
+			reason = "empty term"
+		case e.Translation == "" && !e.DoNotTranslate:
+			reason = "missing translation"
+		case utf8.RuneCountInString(e.Term) > _maxEntryLength:
+			reason = fmt.Sprintf("term longer than %d characters", _maxEntryLength)
+		case utf8.RuneCountInString(e.Translation) > _maxEntryLength:
+			reason = fmt.Sprintf("translation longer than %d characters", _maxEntryLength)
+		case terms[e.Term]:
+			reason = "duplicate term"
+		}
+
+		if reason != "" {
+			return nil, entity.NewAppError(
+				entity.ErrValidation.WithDetails(map[string]string{
+					"field":  "entries",
+					"entry":  strconv.Itoa(i),
+					"term":   e.Term,
+					"reason": reason,
+				}),
+				fmt.Errorf("%s: entry %d: %s", method, i, reason),
+			)
+		}
+
+		terms[e.Term] = true
+
+		checked = append(checked, e)
+	}
+
+	return checked, nil
+}
+
+// repoError keeps entity.ErrNotFound of the repository and reports other errors as internal.
+func repoError(err error) error {
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.NewAppError(entity.ErrNotFound, err)
+	}
+
+	return entity.NewAppError(entity.ErrInternal, err)
+}
@@ -0,0 +1,263 @@
+package glossary
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// lineError is a problem of a malformed file at a line, numbered from 1.
+type lineError struct {
+	format  string
+	line    int
+	message string
+}
+
+func (e *lineError) Error() string {
+	return fmt.Sprintf("%s: line %d: %s", e.format, e.line, e.message)
+}
+
+// csvColumns are the header names of the CSV columns, besides the language codes of the glossary.
+//
+//nolint:gochecknoglobals // lookup table
+var _csvColumns = map[string]string{
+	"term":             "term",
+	"source":           "term",
+	"translation":      "translation",
+	"target":           "translation",
+	"do_not_translate": "do_not_translate",
+	"case_sensitive":   "case_sensitive",
+}
+
+// parseCSV reads the terms of a CSV file, one per record: the term, then its translation. A header record
+// may name the columns term (or the source language), translation (or the destination language),
+// do_not_translate and case_sensitive, in any order. Without a do_not_translate column,
+// terms without a translation or translated as themselves are not translated.
+func parseCSV(content []byte, source, destination string) ([]entity.GlossaryEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{"term": 0, "translation": 1}
+	entries := newEntryList()
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &lineError{format: entity.GlossaryFormatCSV, line: parseErr.Line, message: parseErr.Err.Error()}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+
+		if first {
+			if header, ok := csvHeader(record, source, destination); ok {
+				columns = header
+
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if slices.IndexFunc(record, func(field string) bool { return strings.TrimSpace(field) != "" }) < 0 {
+			continue
+		}
+
+		e := entity.GlossaryEntry{
+			Term:          field(record, columns, "term"),
+			Translation:   field(record, columns, "translation"),
+			CaseSensitive: flag(field(record, columns, "case_sensitive")),
+		}
+
+		if _, ok := columns["do_not_translate"]; ok {
+			e.DoNotTranslate = flag(field(record, columns, "do_not_translate"))
+		} else {
+			e.DoNotTranslate = e.Translation == "" || e.Translation == e.Term
+		}
+
+		if e.Term == "" {
+			return nil, &lineError{format: entity.GlossaryFormatCSV, line: line, message: "empty term"}
+		}
+
+		entries.add(e)
+	}
+
+	if len(entries.entries) == 0 {
+		return nil, errors.New("csv: no terms")
+	}
+
+	return entries.entries, nil
+}
+
+// csvHeader returns the indexes of the named columns of a header record,
+// which names at least the term column.
+func csvHeader(record []string, source, destination string) (map[string]int, bool) {
+	columns := map[string]int{}
+
+	for i, name := range record {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		column, ok := _csvColumns[name]
+
+		switch {
+		case ok:
+		case sameLanguage(name, source):
+			column = "term"
+		case sameLanguage(name, destination):
+			column = "translation"
+		default:
+			continue
+		}
+
+		if _, ok := columns[column]; !ok {
+			columns[column] = i
+		}
+	}
+
+	_, ok := columns["term"]
+
+	return columns, ok
+}
+
+func field(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+func flag(value string) bool {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "y", "x":
+		return true
+	default:
+		return false
+	}
+}
+
+// tbxDocument holds the concepts of TBX-Basic (termEntry, langSet, tig or ntig)
+// and TBX v3 (conceptEntry, langSec, termSec) files.
+type tbxDocument struct {
+	Entries  []tbxConcept `xml:"text>body>termEntry"`
+	Concepts []tbxConcept `xml:"text>body>conceptEntry"`
+}
+
+type tbxConcept struct {
+	LangSets []tbxLanguage `xml:"langSet"`
+	LangSecs []tbxLanguage `xml:"langSec"`
+}
+
+type tbxLanguage struct {
+	Lang      string   `xml:"lang,attr"`
+	Terms     []string `xml:"tig>term"`
+	NTigTerms []string `xml:"ntig>termGrp>term"`
+	SecTerms  []string `xml:"termSec>term"`
+}
+
+func (l tbxLanguage) terms() []string {
+	var terms []string
+
+	for _, term := range slices.Concat(l.Terms, l.NTigTerms, l.SecTerms) {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// parseTBX reads the terms of a TBX file: each term of a concept in the source language
+// is translated by the first term of the concept in the destination language. Concepts
+// without a term in both languages are skipped; terms translated as themselves are not translated.
+func parseTBX(content []byte, source, destination string) ([]entity.GlossaryEntry, error) {
+	var doc tbxDocument
+
+	err := xml.Unmarshal(content, &doc)
+	if err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, &lineError{format: entity.GlossaryFormatTBX, line: syntaxErr.Line, message: syntaxErr.Msg}
+		}
+
+		return nil, fmt.Errorf("tbx: %w", err)
+	}
+
+	entries := newEntryList()
+
+	for _, concept := range slices.Concat(doc.Entries, doc.Concepts) {
+		var terms, translations []string
+
+		for _, l := range slices.Concat(concept.LangSets, concept.LangSecs) {
+			switch {
+			case sameLanguage(l.Lang, source):
+				terms = append(terms, l.terms()...)
+			case sameLanguage(l.Lang, destination):
+				translations = append(translations, l.terms()...)
+			}
+		}
+
+		if len(translations) == 0 {
+			continue
+		}
+
+		for _, term := range terms {
+			entries.add(entity.GlossaryEntry{
+				Term:           term,
+				Translation:    translations[0],
+				DoNotTranslate: translations[0] == term,
+			})
+		}
+	}
+
+	if len(entries.entries) == 0 {
+		return nil, fmt.Errorf("tbx: no terms from %s into %s", source, destination)
+	}
+
+	return entries.entries, nil
+}
+
+// entryList holds the entries of a file once per term: the last one in the file wins.
+type entryList struct {
+	entries []entity.GlossaryEntry
+	index   map[string]int
+}
+
+func newEntryList() *entryList {
+	return &entryList{index: map[string]int{}}
+}
+
+func (l *entryList) add(e entity.GlossaryEntry) {
+	e.Term = strings.Join(strings.Fields(e.Term), " ")
+
+	if i, ok := l.index[e.Term]; ok {
+		l.entries[i] = e
+
+		return
+	}
+
+	l.index[e.Term] = len(l.entries)
+	l.entries = append(l.entries, e)
+}
+
+// sameLanguage reports whether tag is the language code, or a region or script of it.
+func sameLanguage(tag, code string) bool {
+	base, _, _ := strings.Cut(tag, "-")
+
+	return strings.EqualFold(tag, code) || strings.EqualFold(base, code)
+}
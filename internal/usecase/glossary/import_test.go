@@ -0,0 +1,118 @@
+package glossary
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		entries []entity.GlossaryEntry
+	}{
+		{
+			name:    "no header",
+			content: "\ufeffinvoice,hóa đơn\nAcme,Acme\n\n\"sign  in\", đăng nhập\n",
+			entries: []entity.GlossaryEntry{
+				{Term: "invoice", Translation: "hóa đơn"},
+				{Term: "Acme", Translation: "Acme", DoNotTranslate: true},
+				{Term: "sign in", Translation: "đăng nhập"},
+			},
+		},
+		{
+			name:    "named columns",
+			content: "case_sensitive,translation,term,do_not_translate\nyes,,Acme,true\n,hóa đơn,invoice,\n",
+			entries: []entity.GlossaryEntry{
+				{Term: "Acme", DoNotTranslate: true, CaseSensitive: true},
+				{Term: "invoice", Translation: "hóa đơn"},
+			},
+		},
+		{
+			name:    "language columns, last entry wins",
+			content: "note,VI,EN-US\n,hoá đơn,invoice\n,hóa đơn,invoice\n",
+			entries: []entity.GlossaryEntry{
+				{Term: "invoice", Translation: "hóa đơn"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entries, err := parseCSV([]byte(tc.content), "en", "vi")
+			require.NoError(t, err)
+			require.Equal(t, tc.entries, entries)
+		})
+	}
+
+	_, err := parseCSV([]byte("term,translation\n\"invoice,hóa đơn\n"), "en", "vi")
+	require.EqualError(t, err, `csv: line 2: extraneous or missing " in quoted-field`)
+}
+
+func TestParseTBX(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "TBX-Basic",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<martif type="TBX-Basic" xml:lang="en">
+  <text><body>
+    <termEntry id="1">
+      <langSet xml:lang="en-US"><tig><term>invoice</term></tig><tig><term>bill</term></tig></langSet>
+      <langSet xml:lang="vi"><tig><term>hóa đơn</term></tig></langSet>
+    </termEntry>
+    <termEntry id="2">
+      <langSet xml:lang="en"><ntig><termGrp><term>Acme</term></termGrp></ntig></langSet>
+      <langSet xml:lang="vi"><ntig><termGrp><term>Acme</term></termGrp></ntig></langSet>
+    </termEntry>
+    <termEntry id="3">
+      <langSet xml:lang="en"><tig><term>untranslated</term></tig></langSet>
+      <langSet xml:lang="fr"><tig><term>non traduit</term></tig></langSet>
+    </termEntry>
+  </body></text>
+</martif>`,
+		},
+		{
+			name: "TBX v3",
+			content: `<tbx type="TBX-Basic" style="dca" xml:lang="en" xmlns="urn:iso:std:iso:30042:ed-2">
+  <text><body>
+    <conceptEntry id="1">
+      <langSec xml:lang="en"><termSec><term>invoice</term></termSec><termSec><term>bill</term></termSec></langSec>
+      <langSec xml:lang="vi"><termSec><term>hóa đơn</term></termSec></langSec>
+    </conceptEntry>
+    <conceptEntry id="2">
+      <langSec xml:lang="en"><termSec><term>Acme</term></termSec></langSec>
+      <langSec xml:lang="vi"><termSec><term>Acme</term></termSec></langSec>
+    </conceptEntry>
+  </body></text>
+</tbx>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entries, err := parseTBX([]byte(tc.content), "en", "vi")
+			require.NoError(t, err)
+			require.Equal(t, []entity.GlossaryEntry{
+				{Term: "invoice", Translation: "hóa đơn"},
+				{Term: "bill", Translation: "hóa đơn"},
+				{Term: "Acme", Translation: "Acme", DoNotTranslate: true},
+			}, entries)
+		})
+	}
+
+	_, err := parseTBX([]byte("<martif>\n<text>\n</martif>"), "en", "vi")
+	require.EqualError(t, err, "tbx: line 3: element <text> closed by </martif>")
+}
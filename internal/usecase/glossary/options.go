@@ -0,0 +1,14 @@
+package glossary
+
+import "github.com/evrone/go-clean-template/internal/entity"
+
+// Option -.
+type Option func(*UseCase)
+
+// Languages sets the languages of the provider; glossaries with a source or destination outside of them
+// are rejected with entity.ErrValidation. Without languages any code is accepted.
+func Languages(languages []entity.Language) Option {
+	return func(uc *UseCase) {
+		uc.languages = languages
+	}
+}
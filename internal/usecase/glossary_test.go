@@ -0,0 +1,190 @@
+package usecase_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/usecase/glossary"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGlossary(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockGlossaryRepo(mockCtl)
+	useCase := glossary.New(repo, glossary.Languages([]entity.Language{
+		{Code: "en", Source: true, Destination: true},
+		{Code: "vi", Destination: true},
+	}))
+
+	t.Run("stored with entries", func(t *testing.T) {
+		repo.EXPECT().CreateGlossary(gomock.Any(), entity.Glossary{
+			Name:        "Product",
+			Source:      "en",
+			Destination: "vi",
+			Entries: []entity.GlossaryEntry{
+				{Term: "Acme Cloud", DoNotTranslate: true},
+				{Term: "invoice", Translation: "hóa đơn"},
+			},
+		}).Return(entity.Glossary{
+			ID:          7,
+			Name:        "Product",
+			Source:      "en",
+			Destination: "vi",
+			Entries: []entity.GlossaryEntry{
+				{ID: 1, GlossaryID: 7, Term: "Acme Cloud", DoNotTranslate: true},
+				{ID: 2, GlossaryID: 7, Term: "invoice", Translation: "hóa đơn"},
+			},
+		}, nil)
+
+		created, err := useCase.CreateGlossary(t.Context(), entity.Glossary{
+			Name:        " Product ",
+			Source:      "en",
+			Destination: "vi",
+			Entries: []entity.GlossaryEntry{
+				{Term: " Acme   Cloud ", Translation: "ignored", DoNotTranslate: true},
+				{Term: "invoice", Translation: " hóa đơn "},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(7), created.ID)
+		require.Len(t, created.Entries, 2)
+	})
+
+	tests := []struct {
+		name     string
+		glossary entity.Glossary
+		details  map[string]string
+	}{
+		{
+			name:     "empty name",
+			glossary: entity.Glossary{Name: " ", Source: "en", Destination: "vi"},
+			details:  map[string]string{"field": "name"},
+		},
+		{
+			name:     "auto source",
+			glossary: entity.Glossary{Name: "Product", Source: "auto", Destination: "vi"},
+			details:  map[string]string{"field": "source", "reason": "a glossary needs a source language"},
+		},
+		{
+			name:     "unsupported language",
+			glossary: entity.Glossary{Name: "Product", Source: "en-GB", Destination: "xx"},
+			details:  map[string]string{"field": "destination", "language": "xx", "reason": "unsupported language"},
+		},
+		{
+			name:     "unsupported as source",
+			glossary: entity.Glossary{Name: "Product", Source: "VI", Destination: "en"},
+			details:  map[string]string{"field": "source", "language": "VI", "reason": "unsupported as source"},
+		},
+		{
+			name: "missing translation",
+			glossary: entity.Glossary{Name: "Product", Source: "en", Destination: "vi", Entries: []entity.GlossaryEntry{
+				{Term: "invoice"},
+			}},
+			details: map[string]string{"field": "entries", "entry": "0", "term": "invoice", "reason": "missing translation"},
+		},
+		{
+			name: "term too long",
+			glossary: entity.Glossary{Name: "Product", Source: "en", Destination: "vi", Entries: []entity.GlossaryEntry{
+				{Term: strings.Repeat("á", 256), Translation: "hóa đơn"},
+			}},
+			details: map[string]string{
+				"field":  "entries",
+				"entry":  "0",
+				"term":   strings.Repeat("á", 256),
+				"reason": "term longer than 255 characters",
+			},
+		},
+		{
+			name: "translation too long",
+			glossary: entity.Glossary{Name: "Product", Source: "en", Destination: "vi", Entries: []entity.GlossaryEntry{
+				{Term: "invoice", Translation: strings.Repeat("ơ", 256)},
+			}},
+			details: map[string]string{
+				"field":  "entries",
+				"entry":  "0",
+				"term":   "invoice",
+				"reason": "translation longer than 255 characters",
+			},
+		},
+		{
+			name: "duplicate term",
+			glossary: entity.Glossary{Name: "Product", Source: "en", Destination: "vi", Entries: []entity.GlossaryEntry{
+				{Term: "invoice", Translation: "hóa đơn"},
+				{Term: "invoice ", Translation: "biên lai"},
+			}},
+			details: map[string]string{"field": "entries", "entry": "1", "term": "invoice", "reason": "duplicate term"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := useCase.CreateGlossary(t.Context(), tc.glossary)
+
+			appErr := entity.GetAppError(err)
+			require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+			require.Equal(t, tc.details, appErr.Details)
+		})
+	}
+}
+
+func TestGlossaryNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockGlossaryRepo(mockCtl)
+	useCase := glossary.New(repo)
+
+	repo.EXPECT().GetGlossary(gomock.Any(), int64(9)).Return(entity.Glossary{}, fmt.Errorf("glossary 9: %w", entity.ErrNotFound))
+	repo.EXPECT().DeleteEntry(gomock.Any(), int64(9), int64(1)).Return(errInternalServErr)
+
+	_, err := useCase.Glossary(t.Context(), 9)
+	require.Equal(t, entity.ErrNotFound.Code, entity.GetAppError(err).Code)
+
+	err = useCase.DeleteEntry(t.Context(), 9, 1)
+	require.Equal(t, entity.ErrInternal.Code, entity.GetAppError(err).Code)
+}
+
+func TestImportGlossary(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockGlossaryRepo(mockCtl)
+	useCase := glossary.New(repo)
+
+	stored := entity.Glossary{ID: 7, Name: "Product", Source: "en", Destination: "vi"}
+
+	t.Run("csv", func(t *testing.T) {
+		repo.EXPECT().GetGlossary(gomock.Any(), int64(7)).Return(stored, nil).Times(2)
+		repo.EXPECT().StoreEntries(gomock.Any(), int64(7), []entity.GlossaryEntry{
+			{Term: "Acme Cloud", DoNotTranslate: true},
+			{Term: "invoice", Translation: "hóa đơn"},
+		}).Return(nil, nil)
+
+		_, err := useCase.ImportGlossary(t.Context(), entity.GlossaryImport{
+			GlossaryID: 7,
+			Format:     entity.GlossaryFormatCSV,
+			Content:    []byte("en,vi\nAcme Cloud,\ninvoice,hóa đơn\n"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		repo.EXPECT().GetGlossary(gomock.Any(), int64(7)).Return(stored, nil)
+
+		_, err := useCase.ImportGlossary(t.Context(), entity.GlossaryImport{
+			GlossaryID: 7,
+			Format:     entity.GlossaryFormatCSV,
+			Content:    []byte("term,translation\ninvoice,hóa đơn\n,biên lai\n"),
+		})
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "3", appErr.Details["line"])
+		require.Equal(t, "csv: line 3: empty term", appErr.Details["reason"])
+	})
+}
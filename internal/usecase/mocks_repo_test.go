@@ -70,6 +70,163 @@ func (mr *MockTranslationRepoMockRecorder) Store(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTranslationRepo)(nil).Store), arg0, arg1)
 }
 
+// MockGlossaryRepo is a mock of GlossaryRepo interface.
+type MockGlossaryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGlossaryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockGlossaryRepoMockRecorder is the mock recorder for MockGlossaryRepo.
+type MockGlossaryRepoMockRecorder struct {
+	mock *MockGlossaryRepo
+}
+
+// NewMockGlossaryRepo creates a new mock instance.
+func NewMockGlossaryRepo(ctrl *gomock.Controller) *MockGlossaryRepo {
+	mock := &MockGlossaryRepo{ctrl: ctrl}
+	mock.recorder = &MockGlossaryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGlossaryRepo) EXPECT() *MockGlossaryRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateGlossary mocks base method.
+func (m *MockGlossaryRepo) CreateGlossary(arg0 context.Context, arg1 entity.Glossary) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGlossary", arg0, arg1)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGlossary indicates an expected call of CreateGlossary.
+func (mr *MockGlossaryRepoMockRecorder) CreateGlossary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGlossary", reflect.TypeOf((*MockGlossaryRepo)(nil).CreateGlossary), arg0, arg1)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockGlossaryRepo) DeleteEntry(ctx context.Context, glossaryID, entryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, glossaryID, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockGlossaryRepoMockRecorder) DeleteEntry(ctx, glossaryID, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockGlossaryRepo)(nil).DeleteEntry), ctx, glossaryID, entryID)
+}
+
+// DeleteGlossary mocks base method.
+func (m *MockGlossaryRepo) DeleteGlossary(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGlossary", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGlossary indicates an expected call of DeleteGlossary.
+func (mr *MockGlossaryRepoMockRecorder) DeleteGlossary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGlossary", reflect.TypeOf((*MockGlossaryRepo)(nil).DeleteGlossary), ctx, id)
+}
+
+// GetEntries mocks base method.
+func (m *MockGlossaryRepo) GetEntries(ctx context.Context, source, destination string) ([]entity.GlossaryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, source, destination)
+	ret0, _ := ret[0].([]entity.GlossaryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockGlossaryRepoMockRecorder) GetEntries(ctx, source, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockGlossaryRepo)(nil).GetEntries), ctx, source, destination)
+}
+
+// GetGlossaries mocks base method.
+func (m *MockGlossaryRepo) GetGlossaries(arg0 context.Context) ([]entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlossaries", arg0)
+	ret0, _ := ret[0].([]entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGlossaries indicates an expected call of GetGlossaries.
+func (mr *MockGlossaryRepoMockRecorder) GetGlossaries(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlossaries", reflect.TypeOf((*MockGlossaryRepo)(nil).GetGlossaries), arg0)
+}
+
+// GetGlossary mocks base method.
+func (m *MockGlossaryRepo) GetGlossary(ctx context.Context, id int64) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlossary", ctx, id)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGlossary indicates an expected call of GetGlossary.
+func (mr *MockGlossaryRepoMockRecorder) GetGlossary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlossary", reflect.TypeOf((*MockGlossaryRepo)(nil).GetGlossary), ctx, id)
+}
+
+// GetSources mocks base method.
+func (m *MockGlossaryRepo) GetSources(ctx context.Context, destination string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSources", ctx, destination)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSources indicates an expected call of GetSources.
+func (mr *MockGlossaryRepoMockRecorder) GetSources(ctx, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSources", reflect.TypeOf((*MockGlossaryRepo)(nil).GetSources), ctx, destination)
+}
+
+// StoreEntries mocks base method.
+func (m *MockGlossaryRepo) StoreEntries(ctx context.Context, glossaryID int64, entries []entity.GlossaryEntry) ([]entity.GlossaryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEntries", ctx, glossaryID, entries)
+	ret0, _ := ret[0].([]entity.GlossaryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreEntries indicates an expected call of StoreEntries.
+func (mr *MockGlossaryRepoMockRecorder) StoreEntries(ctx, glossaryID, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEntries", reflect.TypeOf((*MockGlossaryRepo)(nil).StoreEntries), ctx, glossaryID, entries)
+}
+
+// UpdateGlossary mocks base method.
+func (m *MockGlossaryRepo) UpdateGlossary(arg0 context.Context, arg1 entity.Glossary) (entity.Glossary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGlossary", arg0, arg1)
+	ret0, _ := ret[0].(entity.Glossary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGlossary indicates an expected call of UpdateGlossary.
+func (mr *MockGlossaryRepoMockRecorder) UpdateGlossary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGlossary", reflect.TypeOf((*MockGlossaryRepo)(nil).UpdateGlossary), arg0, arg1)
+}
+
 // MockTranslationWebAPI is a mock of TranslationWebAPI interface.
 type MockTranslationWebAPI struct {
 	ctrl     *gomock.Controller
@@ -94,6 +251,21 @@ func (m *MockTranslationWebAPI) EXPECT() *MockTranslationWebAPIMockRecorder {
 	return m.recorder
 }
 
+// DetectLanguage mocks base method.
+func (m *MockTranslationWebAPI) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectLanguage", ctx, text)
+	ret0, _ := ret[0].(entity.LanguageDetection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectLanguage indicates an expected call of DetectLanguage.
+func (mr *MockTranslationWebAPIMockRecorder) DetectLanguage(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockTranslationWebAPI)(nil).DetectLanguage), ctx, text)
+}
+
 // Translate mocks base method.
 func (m *MockTranslationWebAPI) Translate(ctx context.Context, translation entity.Translation) (entity.Translation, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +280,82 @@ func (mr *MockTranslationWebAPIMockRecorder) Translate(ctx, translation any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslationWebAPI)(nil).Translate), ctx, translation)
 }
+
+// MockLanguageDetector is a mock of LanguageDetector interface.
+type MockLanguageDetector struct {
+	ctrl     *gomock.Controller
+	recorder *MockLanguageDetectorMockRecorder
+	isgomock struct{}
+}
+
+// MockLanguageDetectorMockRecorder is the mock recorder for MockLanguageDetector.
+type MockLanguageDetectorMockRecorder struct {
+	mock *MockLanguageDetector
+}
+
+// NewMockLanguageDetector creates a new mock instance.
+func NewMockLanguageDetector(ctrl *gomock.Controller) *MockLanguageDetector {
+	mock := &MockLanguageDetector{ctrl: ctrl}
+	mock.recorder = &MockLanguageDetectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLanguageDetector) EXPECT() *MockLanguageDetectorMockRecorder {
+	return m.recorder
+}
+
+// DetectLanguage mocks base method.
+func (m *MockLanguageDetector) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectLanguage", ctx, text)
+	ret0, _ := ret[0].(entity.LanguageDetection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectLanguage indicates an expected call of DetectLanguage.
+func (mr *MockLanguageDetectorMockRecorder) DetectLanguage(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockLanguageDetector)(nil).DetectLanguage), ctx, text)
+}
+
+// MockTranslationCoalescer is a mock of TranslationCoalescer interface.
+type MockTranslationCoalescer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationCoalescerMockRecorder
+	isgomock struct{}
+}
+
+// MockTranslationCoalescerMockRecorder is the mock recorder for MockTranslationCoalescer.
+type MockTranslationCoalescerMockRecorder struct {
+	mock *MockTranslationCoalescer
+}
+
+// NewMockTranslationCoalescer creates a new mock instance.
+func NewMockTranslationCoalescer(ctrl *gomock.Controller) *MockTranslationCoalescer {
+	mock := &MockTranslationCoalescer{ctrl: ctrl}
+	mock.recorder = &MockTranslationCoalescerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationCoalescer) EXPECT() *MockTranslationCoalescerMockRecorder {
+	return m.recorder
+}
+
+// Coalesce mocks base method.
+func (m *MockTranslationCoalescer) Coalesce(ctx context.Context, key string, translate func(context.Context) (entity.Translation, error)) (entity.Translation, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Coalesce", ctx, key, translate)
+	ret0, _ := ret[0].(entity.Translation)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Coalesce indicates an expected call of Coalesce.
+func (mr *MockTranslationCoalescerMockRecorder) Coalesce(ctx, key, translate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Coalesce", reflect.TypeOf((*MockTranslationCoalescer)(nil).Coalesce), ctx, key, translate)
+}
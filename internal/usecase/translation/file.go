@@ -0,0 +1,76 @@
+package translation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// resource is a parsed resource file: its translatable values, in file order, and how to write
+// the file back with the translated values and the destination language.
+// The single-line units of a batched resource are translated together, a unit per line.
+type resource struct {
+	units  []unit
+	batch  bool
+	encode func(translations []string, destination string) ([]byte, error)
+}
+
+// unit is a value of a resource file; parse splits it into the parts sent to the provider.
+type unit struct {
+	text  string
+	parse func(string) document
+}
+
+func parseResource(format string, content []byte) (resource, error) {
+	switch format {
+	case entity.FileFormatJSON:
+		return parseJSONResource(content)
+	case entity.FileFormatYAML:
+		return parseYAMLResource(content)
+	case entity.FileFormatPO:
+		return parsePOResource(content)
+	case entity.FileFormatXLIFF:
+		return parseXLIFFResource(content)
+	case entity.FileFormatSRT:
+		return parseSRTResource(content)
+	case entity.FileFormatVTT:
+		return parseVTTResource(content)
+	default:
+		return resource{}, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// batches groups the indexes of consecutive units translated together, up to size characters
+// with the line breaks. Units of resources without batching, and multi-line units, are translated alone.
+func (r resource) batches(size int) [][]int {
+	var (
+		batches [][]int
+		length  int
+	)
+
+	for i, u := range r.units {
+		n := utf8.RuneCountInString(u.text)
+
+		if !r.batch || strings.Contains(u.text, "\n") {
+			batches = append(batches, []int{i})
+			length = size
+
+			continue
+		}
+
+		last := len(batches) - 1
+		if last >= 0 && length+1+n <= size {
+			batches[last] = append(batches[last], i)
+			length += 1 + n
+
+			continue
+		}
+
+		batches = append(batches, []int{i})
+		length = n
+	}
+
+	return batches
+}
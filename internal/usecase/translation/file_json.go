@@ -0,0 +1,121 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// parseJSONResource makes the string values of a JSON document translatable, at any depth.
+// The translated values are written in place, so keys, their order and the formatting are kept.
+func parseJSONResource(content []byte) (resource, error) {
+	type container struct {
+		object    bool
+		expectKey bool
+	}
+
+	var (
+		stack []container
+		spans [][2]int
+		units []unit
+		prev  int
+	)
+
+	// valueDone marks a value of the enclosing object as read, the next string is a key.
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			if len(stack) > 0 || prev == 0 {
+				return resource{}, fmt.Errorf("json: %w", io.ErrUnexpectedEOF)
+			}
+
+			break
+		}
+
+		if err != nil {
+			return resource{}, fmt.Errorf("json: %w", err)
+		}
+
+		end := int(decoder.InputOffset())
+
+		switch value := token.(type) {
+		case json.Delim:
+			switch value {
+			case '{':
+				stack = append(stack, container{object: true, expectKey: true})
+			case '[':
+				stack = append(stack, container{})
+			default:
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+		case string:
+			if len(stack) > 0 && stack[len(stack)-1].expectKey {
+				stack[len(stack)-1].expectKey = false
+
+				break
+			}
+
+			start := prev + bytes.IndexByte(content[prev:end], '"')
+			spans = append(spans, [2]int{start, end})
+			units = append(units, unit{text: value, parse: parseICU})
+
+			valueDone()
+		default:
+			valueDone()
+		}
+
+		prev = end
+	}
+
+	encode := func(translations []string, _ string) ([]byte, error) {
+		var (
+			out  bytes.Buffer
+			last int
+		)
+
+		for i, span := range spans {
+			value, err := marshalJSONString(translations[i])
+			if err != nil {
+				return nil, err
+			}
+
+			out.Write(content[last:span[0]])
+			out.Write(value)
+
+			last = span[1]
+		}
+
+		out.Write(content[last:])
+
+		return out.Bytes(), nil
+	}
+
+	return resource{units: units, encode: encode}, nil
+}
+
+// marshalJSONString quotes s without escaping HTML characters, which are common in messages.
+func marshalJSONString(s string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(s)
+	if err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
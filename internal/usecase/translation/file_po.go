@@ -0,0 +1,277 @@
+package translation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals // compiled once
+var (
+	_poKeywordPattern  = regexp.MustCompile(`^(msgctxt|msgid_plural|msgid|msgstr(?:\[(\d+)\])?)\s+(".*")\s*$`)
+	_poLanguagePattern = regexp.MustCompile(`(?m)^Language:.*$`)
+
+	_poUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\t`, "\t", `\r`, "\r")
+	_poEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+)
+
+// poEntry is a message of a PO file: its lines and the parsed strings.
+type poEntry struct {
+	lines    []string
+	msgid    string
+	plural   string
+	hasID    bool
+	obsolete bool
+	// msgstr is the index of the first msgstr line, forms the number of msgstr[n] lines.
+	msgstr int
+	forms  int
+	// strLines marks the msgstr lines and their continuations, replaced on encode.
+	strLines map[int]bool
+}
+
+// parsePOResource makes msgid and msgid_plural of gettext messages translatable. Comments, contexts
+// and references are kept; msgstr gets the translation of msgid, msgstr[n] of msgid for the first form
+// and of msgid_plural for the others. The header keeps its fields with Language set to the destination.
+func parsePOResource(content []byte) (resource, error) {
+	entries, err := parsePOEntries(string(content))
+	if err != nil {
+		return resource{}, err
+	}
+
+	var units []unit
+
+	// unitOf holds the unit index of msgid and msgid_plural by entry, -1 if not translated.
+	unitOf := make([][2]int, len(entries))
+
+	for i, entry := range entries {
+		unitOf[i] = [2]int{-1, -1}
+
+		if entry.obsolete || !entry.hasID || entry.msgid == "" {
+			continue
+		}
+
+		unitOf[i][0] = len(units)
+		units = append(units, unit{text: entry.msgid, parse: parsePlain})
+
+		if entry.forms > 0 {
+			unitOf[i][1] = len(units)
+			units = append(units, unit{text: entry.plural, parse: parsePlain})
+		}
+	}
+
+	encode := func(translations []string, destination string) ([]byte, error) {
+		var out strings.Builder
+
+		for i, entry := range entries {
+			if i > 0 {
+				out.WriteString("\n")
+			}
+
+			switch {
+			case entry.hasID && entry.msgid == "" && !entry.obsolete:
+				header, err := entry.msgstrValue()
+				if err != nil {
+					return nil, err
+				}
+
+				writePOEntry(&out, entry, poLines("msgstr", setPOLanguage(header, destination)))
+			case unitOf[i][0] >= 0 && entry.forms > 0:
+				var lines []string
+
+				for form := range entry.forms {
+					translation := translations[unitOf[i][1]]
+					if form == 0 {
+						translation = translations[unitOf[i][0]]
+					}
+
+					lines = append(lines, poLines("msgstr["+strconv.Itoa(form)+"]", translation)...)
+				}
+
+				writePOEntry(&out, entry, lines)
+			case unitOf[i][0] >= 0:
+				writePOEntry(&out, entry, poLines("msgstr", translations[unitOf[i][0]]))
+			default:
+				writePOEntry(&out, entry, nil)
+			}
+		}
+
+		return []byte(out.String()), nil
+	}
+
+	return resource{units: units, encode: encode}, nil
+}
+
+// parsePOEntries splits a PO file into entries separated by blank lines.
+func parsePOEntries(content string) ([]*poEntry, error) {
+	var (
+		entries []*poEntry
+		entry   *poEntry
+		keyword string
+		// value is the string continuation lines are added to, nil for msgstr and msgctxt.
+		value *string
+	)
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			entry, keyword = nil, ""
+
+			continue
+		}
+
+		if entry == nil {
+			entry = &poEntry{msgstr: -1, strLines: map[int]bool{}}
+			entries = append(entries, entry)
+		}
+
+		index := len(entry.lines)
+		entry.lines = append(entry.lines, line)
+
+		if strings.HasPrefix(trimmed, "#~") {
+			entry.obsolete = true
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, `"`) {
+			if keyword == "" {
+				return nil, fmt.Errorf("po: line %d: string without a keyword", n+1)
+			}
+
+			s, err := unquotePO(trimmed)
+			if err != nil {
+				return nil, fmt.Errorf("po: line %d: %w", n+1, err)
+			}
+
+			if value != nil {
+				*value += s
+			}
+
+			entry.strLines[index] = strings.HasPrefix(keyword, "msgstr")
+
+			continue
+		}
+
+		match := _poKeywordPattern.FindStringSubmatch(trimmed)
+		if match == nil {
+			return nil, fmt.Errorf("po: line %d: unexpected %q", n+1, trimmed)
+		}
+
+		s, err := unquotePO(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("po: line %d: %w", n+1, err)
+		}
+
+		keyword, value = match[1], nil
+
+		switch {
+		case keyword == "msgid":
+			entry.hasID = true
+			entry.msgid = s
+			value = &entry.msgid
+		case keyword == "msgid_plural":
+			entry.plural = s
+			value = &entry.plural
+		case strings.HasPrefix(keyword, "msgstr"):
+			entry.strLines[index] = true
+
+			if entry.msgstr < 0 {
+				entry.msgstr = index
+			}
+
+			if match[2] != "" {
+				entry.forms++
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// msgstrValue returns the msgstr of the entry, used for the header.
+func (e *poEntry) msgstrValue() (string, error) {
+	var value strings.Builder
+
+	for i, line := range e.lines {
+		if !e.strLines[i] {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if match := _poKeywordPattern.FindStringSubmatch(trimmed); match != nil {
+			trimmed = match[3]
+		}
+
+		s, err := unquotePO(trimmed)
+		if err != nil {
+			return "", fmt.Errorf("po: header: %w", err)
+		}
+
+		value.WriteString(s)
+	}
+
+	return value.String(), nil
+}
+
+// writePOEntry writes the lines of entry with the msgstr lines replaced by msgstr, nil to keep them.
+func writePOEntry(out *strings.Builder, entry *poEntry, msgstr []string) {
+	for i, line := range entry.lines {
+		if msgstr != nil && entry.strLines[i] {
+			if i == entry.msgstr {
+				for _, l := range msgstr {
+					out.WriteString(l + "\n")
+				}
+			}
+
+			continue
+		}
+
+		out.WriteString(line + "\n")
+	}
+
+	if msgstr != nil && entry.msgstr < 0 {
+		for _, l := range msgstr {
+			out.WriteString(l + "\n")
+		}
+	}
+}
+
+// poLines quotes value for keyword, one line per line of a multi-line value as gettext tools do.
+func poLines(keyword, value string) []string {
+	if !strings.Contains(strings.TrimSuffix(value, "\n"), "\n") {
+		return []string{keyword + ` "` + _poEscaper.Replace(value) + `"`}
+	}
+
+	lines := []string{keyword + ` ""`}
+
+	for line := range strings.Lines(value) {
+		lines = append(lines, `"`+_poEscaper.Replace(line)+`"`)
+	}
+
+	return lines
+}
+
+func unquotePO(quoted string) (string, error) {
+	if len(quoted) < 2 || !strings.HasPrefix(quoted, `"`) || !strings.HasSuffix(quoted, `"`) {
+		return "", fmt.Errorf("malformed string %s", quoted)
+	}
+
+	return _poUnescaper.Replace(quoted[1 : len(quoted)-1]), nil
+}
+
+// setPOLanguage sets the Language field of a PO header.
+func setPOLanguage(header, language string) string {
+	field := "Language: " + language
+
+	if _poLanguagePattern.MatchString(header) {
+		return _poLanguagePattern.ReplaceAllLiteralString(header, field)
+	}
+
+	return header + field + "\n"
+}
@@ -0,0 +1,379 @@
+package translation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const _maxLineErrors = 20
+
+//nolint:gochecknoglobals // compiled once
+var (
+	_srtTimingPattern = regexp.MustCompile(`^(\d{1,2}:\d{2}:\d{2}[,.]\d{3})[ \t]+-->[ \t]+(\d{1,2}:\d{2}:\d{2}[,.]\d{3})(?:[ \t].*)?$`)
+	_vttTimingPattern = regexp.MustCompile(`^((?:\d{2,}:)?\d{2}:\d{2}\.\d{3})[ \t]+-->[ \t]+((?:\d{2,}:)?\d{2}:\d{2}\.\d{3})(?:[ \t].*)?$`)
+	_vttTimestampTag  = regexp.MustCompile(`<(?:\d{2,}:)?\d{2}:\d{2}\.\d{3}>`)
+	_srtIndexPattern  = regexp.MustCompile(`^\d+$`)
+)
+
+// lineError is a problem of a malformed file at a line, numbered from 1.
+type lineError struct {
+	line    int
+	message string
+}
+
+// lineErrors lists the problems of a malformed file, in line order.
+type lineErrors []lineError
+
+func (e lineErrors) Error() string {
+	messages := make([]string, 0, len(e))
+
+	for _, err := range e {
+		messages = append(messages, fmt.Sprintf("line %d: %s", err.line, err.message))
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// subtitles is a subtitle file split into lines, with the cue text lines to translate.
+type subtitles struct {
+	lines   []string
+	newline string
+	// cues holds the indexes of the text lines of each cue.
+	cues [][]int
+	errs lineErrors
+}
+
+func newSubtitles(content []byte) *subtitles {
+	text := string(content)
+
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+	}
+
+	return &subtitles{lines: strings.Split(text, newline), newline: newline}
+}
+
+func (s *subtitles) fail(line int, format string, args ...any) {
+	if len(s.errs) < _maxLineErrors {
+		s.errs = append(s.errs, lineError{line: line + 1, message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// blocks calls fn with the first and the end line of each block of non-blank lines.
+func (s *subtitles) blocks(from int, fn func(first, end int)) {
+	for i := from; i < len(s.lines); {
+		if strings.TrimSpace(s.lines[i]) == "" {
+			i++
+
+			continue
+		}
+
+		end := i
+		for end < len(s.lines) && strings.TrimSpace(s.lines[end]) != "" {
+			end++
+		}
+
+		fn(i, end)
+
+		i = end
+	}
+}
+
+// timing checks the timing line of a cue.
+func (s *subtitles) timing(line int, pattern *regexp.Regexp) bool {
+	match := pattern.FindStringSubmatch(strings.TrimSpace(s.lines[line]))
+	if match == nil {
+		s.fail(line, "invalid cue timing %q", s.lines[line])
+
+		return false
+	}
+
+	start, end := parseTimestamp(match[1]), parseTimestamp(match[2])
+	if start < 0 || end < 0 {
+		s.fail(line, "invalid timestamp in %q", s.lines[line])
+
+		return false
+	}
+
+	if end < start {
+		s.fail(line, "cue ends at %s before it starts at %s", match[2], match[1])
+
+		return false
+	}
+
+	return true
+}
+
+// parseSRTResource makes the text of SubRip cues translatable. Cue numbers, timings and line endings
+// are kept; the lines of a cue are translated together and the translation is split into as many lines.
+func parseSRTResource(content []byte) (resource, error) {
+	s := newSubtitles(content)
+
+	s.blocks(0, func(first, end int) {
+		if !_srtIndexPattern.MatchString(strings.TrimPrefix(strings.TrimSpace(s.lines[first]), "\ufeff")) {
+			s.fail(first, "expected a cue number, got %q", s.lines[first])
+
+			return
+		}
+
+		if first+1 == end {
+			s.fail(first+1, "missing cue timing")
+
+			return
+		}
+
+		if s.timing(first+1, _srtTimingPattern) {
+			s.cues = append(s.cues, lineRange(first+2, end))
+		}
+	})
+
+	return s.resource("srt", parseCueText)
+}
+
+// parseVTTResource makes the text of WebVTT cues translatable. The header, NOTE, STYLE and REGION blocks,
+// cue identifiers, timings and settings are kept; the lines of a cue are translated together
+// and the translation is split into as many lines.
+func parseVTTResource(content []byte) (resource, error) {
+	s := newSubtitles(content)
+
+	header := strings.TrimPrefix(s.lines[0], "\ufeff")
+	if header != "WEBVTT" && !strings.HasPrefix(header, "WEBVTT ") && !strings.HasPrefix(header, "WEBVTT\t") {
+		s.fail(0, "expected WEBVTT, got %q", header)
+
+		return resource{}, fmt.Errorf("vtt: %w", s.errs)
+	}
+
+	// The header block ends at the first blank line.
+	from := 1
+	for from < len(s.lines) && strings.TrimSpace(s.lines[from]) != "" {
+		from++
+	}
+
+	s.blocks(from, func(first, end int) {
+		keyword, _, _ := strings.Cut(strings.TrimSpace(s.lines[first]), " ")
+		if keyword == "NOTE" || keyword == "STYLE" || keyword == "REGION" {
+			return
+		}
+
+		timing := first
+		if !strings.Contains(s.lines[first], "-->") {
+			timing++ // cue identifier
+		}
+
+		if timing == end {
+			s.fail(first+1, "missing cue timing")
+
+			return
+		}
+
+		if s.timing(timing, _vttTimingPattern) {
+			s.cues = append(s.cues, lineRange(timing+1, end))
+		}
+	})
+
+	return s.resource("vtt", parseVTTCueText)
+}
+
+func (s *subtitles) resource(format string, parse func(string) document) (resource, error) {
+	if len(s.errs) > 0 {
+		return resource{}, fmt.Errorf("%s: %w", format, s.errs)
+	}
+
+	var (
+		units []unit
+		// lines holds the text lines translated by each unit.
+		lines [][]int
+	)
+
+	for _, cue := range s.cues {
+		if len(cue) == 0 {
+			continue
+		}
+
+		// Dialogue lines, one per speaker, are translated separately.
+		if strings.HasPrefix(strings.TrimSpace(s.lines[cue[0]]), "-") {
+			for _, line := range cue {
+				units = append(units, unit{text: strings.TrimSpace(s.lines[line]), parse: parse})
+				lines = append(lines, []int{line})
+			}
+
+			continue
+		}
+
+		text := make([]string, 0, len(cue))
+		for _, line := range cue {
+			text = append(text, strings.TrimSpace(s.lines[line]))
+		}
+
+		units = append(units, unit{text: strings.Join(text, " "), parse: parse})
+		lines = append(lines, cue)
+	}
+
+	encode := func(translations []string, _ string) ([]byte, error) {
+		out := make([]string, len(s.lines))
+		copy(out, s.lines)
+
+		// A translation split into fewer lines must not leave a blank line inside the cue, which would end it.
+		unused := map[int]bool{}
+
+		for i, unitLines := range lines {
+			split := splitCueText(translations[i], len(unitLines))
+
+			for j, line := range unitLines {
+				if j < len(split) {
+					out[line] = split[j]
+				} else {
+					unused[line] = true
+				}
+			}
+		}
+
+		kept := make([]string, 0, len(out))
+
+		for i, line := range out {
+			if !unused[i] {
+				kept = append(kept, line)
+			}
+		}
+
+		return []byte(strings.Join(kept, s.newline)), nil
+	}
+
+	return resource{units: units, batch: true, encode: encode}, nil
+}
+
+// splitCueText splits text into at most n lines of similar length, between words outside tags,
+// preferably after punctuation.
+func splitCueText(text string, n int) []string {
+	if n <= 1 {
+		return []string{text}
+	}
+
+	var breaks []int // byte offsets of the spaces text can be split at
+
+	depth := 0
+
+	for i, r := range text {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>' && depth > 0:
+			depth--
+		case r == ' ' && depth == 0:
+			breaks = append(breaks, i)
+		}
+	}
+
+	total := utf8.RuneCountInString(text)
+
+	var (
+		lines []string
+		start int
+		next  int // first candidate in breaks
+	)
+
+	for k := 1; k < n && next < len(breaks); k++ {
+		ideal := total * k / n
+
+		score := func(c int) int {
+			before := text[:breaks[c]]
+			distance := abs(utf8.RuneCountInString(before) - ideal)
+
+			if strings.ContainsAny(before[len(before)-1:], ",.;:!?") {
+				distance -= total / (4 * n) //nolint:mnd // up to an eighth of a line
+			}
+
+			return distance
+		}
+
+		best := next
+		for c := next; c < len(breaks); c++ {
+			if score(c) < score(best) {
+				best = c
+			}
+		}
+
+		lines = append(lines, text[start:breaks[best]])
+		start = breaks[best] + 1
+		next = best + 1
+	}
+
+	return append(lines, text[start:])
+}
+
+// parseCueText keeps the formatting tags of SubRip cue text, which has no character references.
+func parseCueText(text string) document {
+	doc := parseHTML(text)
+	doc.decode, doc.encode = identity, identity
+
+	return doc
+}
+
+// parseVTTCueText keeps the tags and the timestamps of WebVTT cue text.
+func parseVTTCueText(text string) document {
+	doc := parseHTML(text)
+
+	var parts []part
+
+	for _, p := range doc.parts {
+		if !p.translate {
+			parts = append(parts, p)
+
+			continue
+		}
+
+		parts = append(parts, splitPattern(p.raw, _vttTimestampTag)...)
+	}
+
+	doc.parts = parts
+
+	return doc
+}
+
+func lineRange(from, to int) []int {
+	lines := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		lines = append(lines, i)
+	}
+
+	return lines
+}
+
+// parseTimestamp parses hh:mm:ss,mmm, hh:mm:ss.mmm or mm:ss.mmm, -1 if a field is out of range.
+func parseTimestamp(timestamp string) time.Duration {
+	fields := strings.FieldsFunc(timestamp, func(r rune) bool { return r == ':' || r == ',' || r == '.' })
+	if len(fields) == 3 { //nolint:mnd // mm:ss.mmm
+		fields = append([]string{"0"}, fields...)
+	}
+
+	var values [4]int
+
+	for i, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return -1
+		}
+
+		values[i] = value
+	}
+
+	if values[1] > 59 || values[2] > 59 { //nolint:mnd // minutes and seconds
+		return -1
+	}
+
+	return time.Duration(values[0])*time.Hour + time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second + time.Duration(values[3])*time.Millisecond
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
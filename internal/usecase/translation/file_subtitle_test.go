@@ -0,0 +1,133 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSubtitles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		units   []string
+		result  string
+	}{
+		{
+			name:   "srt",
+			format: entity.FileFormatSRT,
+			content: "1\r\n00:00:01,000 --> 00:00:03,500\r\nHello there,\r\n<i>my old friend</i>\r\n\r\n" +
+				"2\r\n00:00:04,000 --> 00:00:05,000\r\n- Who?\r\n  - You. \r\n",
+			units: []string{"Hello there, <i>my old friend</i>", "- Who?", "- You."},
+			result: "1\r\n00:00:01,000 --> 00:00:03,500\r\nHELLO THERE,\r\n<i>MY OLD FRIEND</i>\r\n\r\n" +
+				"2\r\n00:00:04,000 --> 00:00:05,000\r\n- WHO?\r\n- YOU.\r\n",
+		},
+		{
+			name:   "vtt",
+			format: entity.FileFormatVTT,
+			content: "WEBVTT - Film\n\nNOTE Keep this\n\nSTYLE\n::cue { color: yellow }\n\n" +
+				"intro\n00:01.000 --> 00:04.000 line:90%\n<v Roger>Fish &amp; chips <00:02.000>tonight\n\n" +
+				"00:05.000 --> 00:06.000\nOne\nlonger line\n",
+			units: []string{"<v Roger>Fish &amp; chips <00:02.000>tonight", "One longer line"},
+			result: "WEBVTT - Film\n\nNOTE Keep this\n\nSTYLE\n::cue { color: yellow }\n\n" +
+				"intro\n00:01.000 --> 00:04.000 line:90%\n<v Roger>FISH &amp; CHIPS <00:02.000>TONIGHT\n\n" +
+				"00:05.000 --> 00:06.000\nONE LONGER\nLINE\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := parseResource(tc.format, []byte(tc.content))
+			require.NoError(t, err)
+
+			units := make([]string, 0, len(res.units))
+			translations := make([]string, 0, len(res.units))
+
+			for _, u := range res.units {
+				units = append(units, u.text)
+				translations = append(translations, upper(u))
+			}
+
+			require.Equal(t, tc.units, units)
+
+			result, err := res.encode(translations, "vi")
+			require.NoError(t, err)
+			require.Equal(t, tc.result, string(result))
+		})
+	}
+}
+
+func TestParseSubtitlesErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		err     string
+	}{
+		{
+			name:    "srt errors by line",
+			format:  entity.FileFormatSRT,
+			content: "1\n00:00:01,000 -> 00:00:02,000\nText\n\nA\n00:00:03,000 --> 00:00:04,000\nText\n\n3\n00:00:06,000 --> 00:00:05,000\nText\n\n4\n",
+			err: `srt: line 2: invalid cue timing "00:00:01,000 -> 00:00:02,000"; line 5: expected a cue number, got "A"; ` +
+				`line 10: cue ends at 00:00:05,000 before it starts at 00:00:06,000; line 14: missing cue timing`,
+		},
+		{
+			name:    "vtt header",
+			format:  entity.FileFormatVTT,
+			content: "WEBVTTX\n\n00:01.000 --> 00:02.000\nText\n",
+			err:     `vtt: line 1: expected WEBVTT, got "WEBVTTX"`,
+		},
+		{
+			name:    "vtt timing",
+			format:  entity.FileFormatVTT,
+			content: "WEBVTT\n\nid\n00:01.000 --> 00:61.000\nText\n",
+			err:     `vtt: line 4: invalid timestamp in "00:01.000 --> 00:61.000"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseResource(tc.format, []byte(tc.content))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestSplitCueText(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"one two", "three four"}, splitCueText("one two three four", 2))
+	require.Equal(t, []string{`<font color="red">a</font>`, "b"}, splitCueText(`<font color="red">a</font> b`, 2))
+	require.Equal(t, []string{"Well, I think", "it is fine"}, splitCueText("Well, I think it is fine", 2))
+	require.Equal(t, []string{"word"}, splitCueText("word", 2))
+}
+
+func TestResourceBatches(t *testing.T) {
+	t.Parallel()
+
+	units := func(texts ...string) []unit {
+		out := make([]unit, 0, len(texts))
+		for _, text := range texts {
+			out = append(out, unit{text: text, parse: parseCueText})
+		}
+
+		return out
+	}
+
+	res := resource{units: units("One", "Two", "Three", "Four\nlines", "Five", "Six"), batch: true}
+	require.Equal(t, [][]int{{0, 1}, {2}, {3}, {4, 5}}, res.batches(8))
+	require.Equal(t, [][]int{{0, 1, 2}, {3}, {4, 5}}, res.batches(20))
+
+	res.batch = false
+	require.Equal(t, [][]int{{0}, {1}, {2}, {3}, {4}, {5}}, res.batches(20))
+}
@@ -0,0 +1,250 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// upper translates the parts of a unit as translateParts does, with strings.ToUpper as the provider.
+func upper(u unit) string {
+	doc := u.parse(u.text)
+
+	var out strings.Builder
+
+	for _, p := range doc.parts {
+		node := newChunk(p.raw)
+		text := doc.decode(node.text)
+
+		if !p.translate || !hasLetter(text) {
+			out.WriteString(p.raw)
+
+			continue
+		}
+
+		out.WriteString(node.leading + doc.encode(strings.ToUpper(text)) + node.trailing)
+	}
+
+	return out.String()
+}
+
+func TestParseResource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		units   []string
+		result  string
+	}{
+		{
+			name:   "json nested keys",
+			format: entity.FileFormatJSON,
+			content: `{
+  "title": "Hello <b>{name}</b>",
+  "menu": {"open": "Open \"file\"", "count": 3, "items": ["One", "Two"], "empty": ""}
+}
+`,
+			units: []string{"Hello <b>{name}</b>", `Open "file"`, "One", "Two", ""},
+			result: `{
+  "title": "HELLO <B>{NAME}</B>",
+  "menu": {"open": "OPEN \"FILE\"", "count": 3, "items": ["ONE", "TWO"], "empty": ""}
+}
+`,
+		},
+		{
+			name:    "json ICU plural",
+			format:  entity.FileFormatJSON,
+			content: `{"items": "{count, plural, =0 {No items} one {# item} other {# items}}"}`,
+			units:   []string{"{count, plural, =0 {No items} one {# item} other {# items}}"},
+			result:  `{"items": "{count, plural, =0 {NO ITEMS} one {# ITEM} other {# ITEMS}}"}`,
+		},
+		{
+			name:   "yaml comments and plurals",
+			format: entity.FileFormatYAML,
+			content: `# Greetings
+en:
+    hello: Hello # inline
+    items:
+        one: One item
+        other: '%{count} items'
+    enabled: true
+`,
+			units: []string{"Hello", "One item", "%{count} items"},
+			result: `# Greetings
+en:
+    hello: HELLO # inline
+    items:
+        one: ONE ITEM
+        other: '%{COUNT} ITEMS'
+    enabled: true
+`,
+		},
+		{
+			name:   "po header, context and plurals",
+			format: entity.FileFormatPO,
+			content: `# Translations
+msgid ""
+msgstr ""
+"Language: en\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#. A comment
+#: src/main.c:10
+msgctxt "menu"
+msgid "Open"
+msgstr ""
+
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] ""
+msgstr[1] ""
+
+msgid ""
+"Long "
+"text"
+msgstr "old"
+
+#~ msgid "Obsolete"
+#~ msgstr "Obsolete"
+`,
+			units: []string{"Open", "One file", "%d files", "Long text"},
+			result: `# Translations
+msgid ""
+msgstr ""
+"Language: vi\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#. A comment
+#: src/main.c:10
+msgctxt "menu"
+msgid "Open"
+msgstr "OPEN"
+
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] "ONE FILE"
+msgstr[1] "%D FILES"
+
+msgid ""
+"Long "
+"text"
+msgstr "LONG TEXT"
+
+#~ msgid "Obsolete"
+#~ msgstr "Obsolete"
+`,
+		},
+		{
+			name:   "xliff 1.2",
+			format: entity.FileFormatXLIFF,
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2">
+  <file source-language="en" datatype="plaintext" original="app">
+    <body>
+      <trans-unit id="greeting">
+        <source>Hello <g id="1">world</g> &amp; you</source>
+        <note>Shown on start</note>
+      </trans-unit>
+      <trans-unit id="old">
+        <source>Bye</source>
+        <target state="needs-translation">Au revoir</target>
+      </trans-unit>
+      <trans-unit id="brand" translate="no">
+        <source>Acme</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`,
+			units: []string{`Hello <g id="1">world</g> &amp; you`, "Bye"},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2">
+  <file source-language="en" datatype="plaintext" original="app" target-language="vi">
+    <body>
+      <trans-unit id="greeting">
+        <source>Hello <g id="1">world</g> &amp; you</source>
+        <target>HELLO <g id="1">WORLD</g> &amp; YOU</target>
+        <note>Shown on start</note>
+      </trans-unit>
+      <trans-unit id="old">
+        <source>Bye</source>
+        <target state="needs-translation">BYE</target>
+      </trans-unit>
+      <trans-unit id="brand" translate="no">
+        <source>Acme</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`,
+		},
+		{
+			name:   "xliff 2.0",
+			format: entity.FileFormatXLIFF,
+			content: `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="fr">
+  <file id="f1">
+    <unit id="u1">
+      <segment>
+        <source>Save <ph id="1"/> now</source>
+        <target/>
+      </segment>
+    </unit>
+  </file>
+</xliff>`,
+			units: []string{`Save <ph id="1"/> now`},
+			result: `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="vi">
+  <file id="f1">
+    <unit id="u1">
+      <segment>
+        <source>Save <ph id="1"/> now</source>
+        <target>SAVE <ph id="1"/> NOW</target>
+      </segment>
+    </unit>
+  </file>
+</xliff>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := parseResource(tc.format, []byte(tc.content))
+			require.NoError(t, err)
+
+			units := make([]string, 0, len(res.units))
+			translations := make([]string, 0, len(res.units))
+
+			for _, u := range res.units {
+				units = append(units, u.text)
+				translations = append(translations, upper(u))
+			}
+
+			require.Equal(t, tc.units, units)
+
+			result, err := res.encode(translations, "vi")
+			require.NoError(t, err)
+			require.Equal(t, tc.result, string(result))
+		})
+	}
+}
+
+func TestParseResourceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		entity.FileFormatJSON:  `{"a": "b",}`,
+		entity.FileFormatYAML:  "a: [b",
+		entity.FileFormatPO:    "msgid \"a\"\nmsgstr b\n",
+		entity.FileFormatXLIFF: "<xliff><file></xliff>",
+	}
+
+	for format, content := range tests {
+		_, err := parseResource(format, []byte(content))
+		require.Error(t, err, format)
+	}
+}
@@ -0,0 +1,247 @@
+package translation
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+//nolint:gochecknoglobals // XML text escaping without the HTML entities
+var _xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// xliffEdit replaces content[start:end] with prefix, the translation of unit, if any, and suffix.
+type xliffEdit struct {
+	start, end     int
+	prefix, suffix string
+	unit           int
+}
+
+// xliffLanguageTag is a start tag to set the target language attribute on.
+type xliffLanguageTag struct {
+	start, end int
+	attr       string
+}
+
+// xliffSegment is a translation unit being read: <trans-unit> in XLIFF 1.2, <segment> in 2.0.
+type xliffSegment struct {
+	skip bool
+	// The source element: whitespace before it, its content and the end of its end tag.
+	indent                  string
+	innerStart, innerEnd    int
+	sourceEnd               int
+	hasSource               bool
+	targetStart, targetOpen int // start and end of the <target> start tag
+	targetEnd               int
+	hasTarget               bool
+	targetSelfClosing       bool
+}
+
+// parseXLIFFResource makes the sources of XLIFF 1.2 and 2.0 units translatable, keeping inline
+// elements, and writes each translation into the target element next to its source, adding it if needed.
+// The target language is set on <file> (1.2) or <xliff> (2.0); everything else is kept byte for byte.
+// Units with translate="no" are left as they are.
+func parseXLIFFResource(content []byte) (resource, error) {
+	var (
+		units        []unit
+		edits        []xliffEdit
+		segment      *xliffSegment
+		skipUnit     bool
+		prevText     string
+		languageTags []xliffLanguageTag
+	)
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = true
+
+	// RawToken does not match end tags with start tags, open holds the open elements to check them.
+	var open []xml.Name
+
+	sourceDepth := -1
+
+	for {
+		start := int(decoder.InputOffset())
+
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			if len(open) > 0 {
+				return resource{}, fmt.Errorf("xliff: unexpected EOF, <%s> is not closed", open[len(open)-1].Local)
+			}
+
+			break
+		}
+
+		if err != nil {
+			return resource{}, fmt.Errorf("xliff: %w", err)
+		}
+
+		end := int(decoder.InputOffset())
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name)
+			depth := len(open)
+
+			switch t.Name.Local {
+			case "xliff":
+				if !strings.HasPrefix(xmlAttr(t, "version"), "1.") {
+					languageTags = append(languageTags, xliffLanguageTag{start: start, end: end, attr: "trgLang"})
+				}
+			case "file":
+				if xmlAttr(t, "source-language") != "" {
+					languageTags = append(languageTags, xliffLanguageTag{start: start, end: end, attr: "target-language"})
+				}
+			case "unit":
+				skipUnit = xmlAttr(t, "translate") == "no"
+			case "trans-unit", "segment":
+				segment = &xliffSegment{skip: skipUnit || xmlAttr(t, "translate") == "no"}
+			case "source":
+				if segment != nil && sourceDepth < 0 {
+					sourceDepth = depth
+					segment.indent = prevText
+					segment.innerStart = end
+				}
+			case "target":
+				if segment != nil && sourceDepth < 0 {
+					segment.hasTarget = true
+					segment.targetStart, segment.targetOpen = start, end
+					segment.targetEnd = end
+					segment.targetSelfClosing = bytes.HasSuffix(content[start:end], []byte("/>"))
+				}
+			}
+		case xml.EndElement:
+			depth := len(open)
+			if depth == 0 || open[depth-1] != t.Name {
+				line, _ := decoder.InputPos()
+
+				return resource{}, fmt.Errorf("xliff: line %d: unexpected </%s>", line, t.Name.Local)
+			}
+
+			open = open[:depth-1]
+
+			switch {
+			case t.Name.Local == "source" && depth == sourceDepth:
+				segment.innerEnd, segment.sourceEnd, segment.hasSource = start, end, true
+				sourceDepth = -1
+			case t.Name.Local == "target" && segment != nil && segment.hasTarget && sourceDepth < 0:
+				segment.targetEnd = end
+			case (t.Name.Local == "trans-unit" || t.Name.Local == "segment") && segment != nil:
+				if !segment.skip && segment.hasSource {
+					edits = append(edits, segment.edit(content, len(units)))
+					units = append(units, unit{
+						text:  string(content[segment.innerStart:segment.innerEnd]),
+						parse: parseXMLInline,
+					})
+				}
+
+				segment = nil
+			}
+		case xml.CharData:
+			prevText = string(t)
+		}
+
+		if _, ok := token.(xml.CharData); !ok {
+			prevText = ""
+		}
+	}
+
+	encode := func(translations []string, destination string) ([]byte, error) {
+		all := make([]xliffEdit, 0, len(edits)+len(languageTags))
+
+		for _, tag := range languageTags {
+			all = append(all, xliffEdit{
+				start:  tag.start,
+				end:    tag.end,
+				prefix: setXMLAttr(string(content[tag.start:tag.end]), tag.attr, destination),
+				unit:   -1,
+			})
+		}
+
+		all = append(all, edits...)
+		slices.SortFunc(all, func(a, b xliffEdit) int { return cmp.Compare(a.start, b.start) })
+
+		var (
+			out  bytes.Buffer
+			last int
+		)
+
+		for _, edit := range all {
+			out.Write(content[last:edit.start])
+			out.WriteString(edit.prefix)
+
+			if edit.unit >= 0 {
+				out.WriteString(translations[edit.unit])
+			}
+
+			out.WriteString(edit.suffix)
+
+			last = edit.end
+		}
+
+		out.Write(content[last:])
+
+		return out.Bytes(), nil
+	}
+
+	return resource{units: units, encode: encode}, nil
+}
+
+// edit replaces the target of the segment, or adds one after the source, aligned with it.
+func (s *xliffSegment) edit(content []byte, unit int) xliffEdit {
+	if !s.hasTarget {
+		return xliffEdit{
+			start:  s.sourceEnd,
+			end:    s.sourceEnd,
+			prefix: s.indent + "<target>",
+			suffix: "</target>",
+			unit:   unit,
+		}
+	}
+
+	open := string(content[s.targetStart:s.targetOpen])
+	if s.targetSelfClosing {
+		open = strings.TrimSuffix(strings.TrimSuffix(open, "/>"), " ") + ">"
+	}
+
+	return xliffEdit{start: s.targetStart, end: s.targetEnd, prefix: open, suffix: "</target>", unit: unit}
+}
+
+// parseXMLInline is parseHTML for XLIFF content: inline elements are kept and the text is escaped for XML.
+func parseXMLInline(content string) document {
+	doc := parseHTML(content)
+	doc.encode = _xmlEscaper.Replace
+
+	return doc
+}
+
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// setXMLAttr sets the attribute name of a start tag, keeping the rest of the tag as written.
+func setXMLAttr(tag, name, value string) string {
+	value = strings.ReplaceAll(_xmlEscaper.Replace(value), `"`, "&quot;")
+
+	pattern := regexp.MustCompile(`(\s` + regexp.QuoteMeta(name) + `\s*=\s*)(?:"[^"]*"|'[^']*')`)
+	if pattern.MatchString(tag) {
+		return pattern.ReplaceAllString(tag, `${1}"`+strings.ReplaceAll(value, "$", "$$")+`"`)
+	}
+
+	end := len(tag) - 1
+	if strings.HasSuffix(tag, "/>") {
+		end--
+	}
+
+	return strings.TrimRight(tag[:end], " ") + " " + name + `="` + value + `"` + tag[end:]
+}
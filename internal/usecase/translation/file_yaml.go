@@ -0,0 +1,102 @@
+package translation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const _defaultYAMLIndent = 2
+
+// parseYAMLResource makes the string values of YAML documents translatable, at any depth.
+// Keys, their order, comments, anchors and scalar styles are kept; the file is re-indented consistently.
+func parseYAMLResource(content []byte) (resource, error) {
+	var (
+		documents []*yaml.Node
+		nodes     []*yaml.Node
+		units     []unit
+	)
+
+	var walk func(node *yaml.Node)
+
+	walk = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(child)
+			}
+		case yaml.MappingNode:
+			for i := 1; i < len(node.Content); i += 2 {
+				walk(node.Content[i])
+			}
+		case yaml.ScalarNode:
+			if node.ShortTag() == "!!str" {
+				nodes = append(nodes, node)
+				units = append(units, unit{text: node.Value, parse: parseICU})
+			}
+		case yaml.AliasNode:
+		}
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var document yaml.Node
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return resource{}, fmt.Errorf("yaml: %w", err)
+		}
+
+		walk(&document)
+		documents = append(documents, &document)
+	}
+
+	encode := func(translations []string, _ string) ([]byte, error) {
+		for i, node := range nodes {
+			node.Value = translations[i]
+		}
+
+		var out bytes.Buffer
+
+		encoder := yaml.NewEncoder(&out)
+		encoder.SetIndent(yamlIndent(content))
+
+		for _, document := range documents {
+			err := encoder.Encode(document)
+			if err != nil {
+				return nil, fmt.Errorf("yaml: %w", err)
+			}
+		}
+
+		err := encoder.Close()
+		if err != nil {
+			return nil, fmt.Errorf("yaml: %w", err)
+		}
+
+		return out.Bytes(), nil
+	}
+
+	return resource{units: units, encode: encode}, nil
+}
+
+// yamlIndent returns the indentation of the first indented line, which is the file's indentation step.
+func yamlIndent(content []byte) int {
+	for line := range strings.Lines(string(content)) {
+		trimmed := strings.TrimLeft(line, " ")
+		if indent := len(line) - len(trimmed); indent > 0 && strings.TrimSpace(trimmed) != "" &&
+			!strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "- ") {
+			return indent
+		}
+	}
+
+	return _defaultYAMLIndent
+}
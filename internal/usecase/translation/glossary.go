@@ -0,0 +1,241 @@
+package translation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// glossary finds the terms of glossary entries in texts sent to the provider.
+type glossary struct {
+	// entries are sorted longest term first, pattern has one group per entry in this order.
+	entries []entity.GlossaryEntry
+	pattern *regexp.Regexp
+	// key identifies the entries in request keys.
+	key string
+}
+
+// glossary returns the glossary entries from the source of t into its destination, nil if there are none.
+// An auto source is detected first, when glossaries translate into the destination, so their entries apply.
+func (uc *UseCase) glossary(ctx context.Context, method string, t entity.Translation) (*glossary, error) {
+	if uc.glossaries == nil {
+		return nil, nil //nolint:nilnil // no glossary
+	}
+
+	source := t.Source
+
+	if strings.EqualFold(source, entity.LanguageAuto) {
+		var err error
+
+		source, err = uc.glossarySource(ctx, method, t)
+		if err != nil || source == "" {
+			return nil, err
+		}
+	}
+
+	// Tags without glossaries of their own use those of their language, e.g. en-US into de those of en into de.
+	directions := [][2]string{{source, t.Destination}}
+
+	base := [2]string{baseLanguage(source), baseLanguage(t.Destination)}
+	if !strings.EqualFold(base[0], source) || !strings.EqualFold(base[1], t.Destination) {
+		directions = append(directions, base)
+	}
+
+	var entries []entity.GlossaryEntry
+
+	for _, direction := range directions {
+		var err error
+
+		entries, err = uc.glossaries.GetEntries(ctx, direction[0], direction[1])
+		if err != nil {
+			return nil, entity.NewAppError(entity.ErrInternal, fmt.Errorf("%s - s.glossaries.GetEntries: %w", method, err))
+		}
+
+		if len(entries) > 0 {
+			break
+		}
+	}
+
+	return newGlossary(entries), nil
+}
+
+// glossarySource returns the glossary source the text of t is detected in, "" if no glossary
+// into the destination has that source or the text has no letters to detect.
+func (uc *UseCase) glossarySource(ctx context.Context, method string, t entity.Translation) (string, error) {
+	sources, err := uc.glossaries.GetSources(ctx, t.Destination)
+	if err != nil {
+		return "", entity.NewAppError(entity.ErrInternal, fmt.Errorf("%s - s.glossaries.GetSources: %w", method, err))
+	}
+
+	if len(sources) == 0 {
+		return "", nil
+	}
+
+	detection, err := uc.DetectLanguage(ctx, t.Original)
+	if errors.Is(err, entity.ErrValidation) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%s - uc.DetectLanguage: %w", method, err)
+	}
+
+	// An exact tag wins over a tag of the same language, e.g. en-US for en.
+	if i := slices.IndexFunc(sources, func(s string) bool { return strings.EqualFold(s, detection.Language) }); i >= 0 {
+		return sources[i], nil
+	}
+
+	if i := slices.IndexFunc(sources, func(s string) bool { return baseLanguage(s) == baseLanguage(detection.Language) }); i >= 0 {
+		return sources[i], nil
+	}
+
+	return "", nil
+}
+
+func newGlossary(entries []entity.GlossaryEntry) *glossary {
+	entries = slices.DeleteFunc(slices.Clone(entries), func(e entity.GlossaryEntry) bool {
+		return strings.TrimSpace(e.Term) == ""
+	})
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Alternatives are tried in order: longer terms win over the terms they contain.
+	slices.SortStableFunc(entries, func(a, b entity.GlossaryEntry) int {
+		return cmp.Compare(utf8.RuneCountInString(b.Term), utf8.RuneCountInString(a.Term))
+	})
+
+	groups := make([]string, 0, len(entries))
+
+	var key strings.Builder
+
+	for _, e := range entries {
+		words := strings.Fields(e.Term)
+		for i, w := range words {
+			words[i] = regexp.QuoteMeta(w)
+		}
+
+		// The words of a term match across line breaks and repeated spaces.
+		group := strings.Join(words, `\s+`)
+		if !e.CaseSensitive {
+			group = "(?i:" + group + ")"
+		}
+
+		groups = append(groups, "("+group+")")
+
+		fmt.Fprintf(&key, "%d\x00%s\x00%s\x00%t\x00%t\x00", e.ID, e.Term, e.Translation, e.DoNotTranslate, e.CaseSensitive)
+	}
+
+	return &glossary{
+		entries: entries,
+		pattern: regexp.MustCompile(strings.Join(groups, "|")),
+		key:     key.String(),
+	}
+}
+
+// mask replaces the glossary terms of text with tokens numbered after the placeholders, which are put back
+// as the translation of the entry or, for do-not-translate entries, as the term was written.
+// It returns the placeholders with the terms and the entries found, in text order.
+func (g *glossary) mask(text string, placeholders []string) (string, []string, []entity.GlossaryEntry) {
+	if g == nil {
+		return text, placeholders, nil
+	}
+
+	var (
+		out     strings.Builder
+		applied []entity.GlossaryEntry
+		last    int
+	)
+
+	for from := 0; from < len(text); {
+		match := g.pattern.FindStringSubmatchIndex(text[from:])
+		if match == nil {
+			break
+		}
+
+		start, end := from+match[0], from+match[1]
+
+		if !wordBoundary(text, start) || !wordBoundary(text, end) {
+			// A shorter term may still match from the next character.
+			_, size := utf8.DecodeRuneInString(text[start:])
+			from = start + size
+
+			continue
+		}
+
+		e := g.entries[matchedGroup(match)]
+
+		replacement := e.Translation
+		if e.DoNotTranslate {
+			replacement = text[start:end]
+		}
+
+		out.WriteString(text[last:start])
+		out.WriteString("⟦" + strconv.Itoa(len(placeholders)) + "⟧")
+
+		placeholders = append(placeholders, replacement)
+
+		if !slices.ContainsFunc(applied, func(a entity.GlossaryEntry) bool { return a.ID == e.ID }) {
+			applied = append(applied, e)
+		}
+
+		last, from = end, end
+	}
+
+	out.WriteString(text[last:])
+
+	return out.String(), placeholders, applied
+}
+
+// matchedGroup returns the index of the first group of a match which matched.
+func matchedGroup(match []int) int {
+	for i := 1; 2*i < len(match); i++ {
+		if match[2*i] >= 0 {
+			return i - 1
+		}
+	}
+
+	return 0
+}
+
+// wordBoundary reports whether offset i of text is not inside a word. Scripts written without spaces
+// between words have a boundary between any two characters.
+func wordBoundary(text string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:i])
+	after, _ := utf8.DecodeRuneInString(text[i:])
+
+	return i == 0 || i == len(text) || !inWord(before) || !inWord(after)
+}
+
+func inWord(r rune) bool {
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r) {
+		return false
+	}
+
+	return !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao,
+		unicode.Khmer, unicode.Myanmar)
+}
+
+// mergeGlossaryEntries returns the glossary entries applied to the results, once each, in order.
+func mergeGlossaryEntries(results []entity.Translation) []entity.GlossaryEntry {
+	var entries []entity.GlossaryEntry
+
+	for _, r := range results {
+		for _, e := range r.GlossaryEntries {
+			if !slices.ContainsFunc(entries, func(a entity.GlossaryEntry) bool { return a.ID == e.ID }) {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,79 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlossaryMask(t *testing.T) {
+	t.Parallel()
+
+	g := newGlossary([]entity.GlossaryEntry{
+		{ID: 1, Term: "cloud", Translation: "đám mây"},
+		{ID: 2, Term: "Acme Cloud", DoNotTranslate: true},
+		{ID: 3, Term: "Sign in", Translation: "Đăng nhập", CaseSensitive: true},
+		{ID: 4, Term: "C++", DoNotTranslate: true},
+		{ID: 5, Term: "東京", Translation: "Tokyo"},
+	})
+
+	tests := []struct {
+		name         string
+		text         string
+		masked       string
+		placeholders []string
+		applied      []int64
+	}{
+		{
+			name:         "longest term first",
+			text:         "Acme  cloud stores your cloud files.",
+			masked:       "⟦1⟧ stores your ⟦2⟧ files.",
+			placeholders: []string{"Acme  cloud", "đám mây"},
+			applied:      []int64{2, 1},
+		},
+		{
+			name:         "whole words",
+			text:         "Cloudy clouds, CLOUD.",
+			masked:       "Cloudy clouds, ⟦1⟧.",
+			placeholders: []string{"đám mây"},
+			applied:      []int64{1},
+		},
+		{
+			name:   "case sensitive",
+			text:   "sign in to continue",
+			masked: "sign in to continue",
+		},
+		{
+			name:         "symbols and scripts without spaces",
+			text:         "C++ in 東京で",
+			masked:       "⟦1⟧ in ⟦2⟧で",
+			placeholders: []string{"C++", "Tokyo"},
+			applied:      []int64{4, 5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			masked, placeholders, applied := g.mask(tc.text, []string{"{name}"})
+			require.Equal(t, tc.masked, masked)
+			require.Equal(t, append([]string{"{name}"}, tc.placeholders...), placeholders)
+
+			var ids []int64
+			for _, e := range applied {
+				ids = append(ids, e.ID)
+			}
+
+			require.Equal(t, tc.applied, ids)
+		})
+	}
+
+	var none *glossary
+
+	masked, placeholders, applied := none.mask("cloud", nil)
+	require.Equal(t, "cloud", masked)
+	require.Empty(t, placeholders)
+	require.Empty(t, applied)
+}
@@ -0,0 +1,141 @@
+package translation
+
+import (
+	"regexp"
+)
+
+//nolint:gochecknoglobals // compiled once
+var (
+	_icuArgumentPattern = regexp.MustCompile(`^\{\s*[\w.-]+\s*,\s*(plural|selectordinal|select)\s*,`)
+	_icuSelectorPattern = regexp.MustCompile(`^\s*(?:offset:\s*\d+\s+)?(?:=?[\w-]+)\s*\{`)
+	_icuClosePattern    = regexp.MustCompile(`^\s*\}`)
+)
+
+// parseICU splits an ICU MessageFormat message so that only the text of its plural, select and
+// selectordinal sub-messages is translated; argument names, keywords, selectors and # are kept.
+// Simple arguments such as {name} stay in the text and are masked as placeholders.
+// A message that does not parse is translated as a whole.
+func parseICU(message string) document {
+	p := icuParser{message: message}
+
+	if !p.parse(false) || p.pos != len(message) {
+		return parsePlain(message)
+	}
+
+	p.flush(len(message))
+
+	return document{parts: p.parts, decode: identity, encode: identity}
+}
+
+// parsePlain makes the whole text translatable.
+func parsePlain(text string) document {
+	return document{parts: []part{{raw: text, translate: true}}, decode: identity, encode: identity}
+}
+
+func identity(s string) string {
+	return s
+}
+
+type icuParser struct {
+	message string
+	pos     int
+	start   int // start of the current text
+	parts   []part
+}
+
+// parse reads a message up to the closing brace of its sub-message or the end.
+func (p *icuParser) parse(plural bool) bool {
+	for p.pos < len(p.message) {
+		switch p.message[p.pos] {
+		case '}':
+			return true
+		case '#':
+			if plural {
+				p.keep(p.pos + 1)
+
+				continue
+			}
+
+			p.pos++
+		case '{':
+			match := _icuArgumentPattern.FindStringSubmatch(p.message[p.pos:])
+			if match == nil {
+				if !p.skipArgument() {
+					return false
+				}
+
+				continue
+			}
+
+			if !p.parseComplex(plural || match[1] != "select", len(match[0])) {
+				return false
+			}
+		default:
+			p.pos++
+		}
+	}
+
+	return true
+}
+
+// parseComplex reads the selectors and sub-messages of a plural, select or selectordinal argument.
+func (p *icuParser) parseComplex(plural bool, header int) bool {
+	p.keep(p.pos + header)
+
+	for {
+		if match := _icuClosePattern.FindString(p.message[p.pos:]); match != "" {
+			p.keep(p.pos + len(match))
+
+			return true
+		}
+
+		match := _icuSelectorPattern.FindString(p.message[p.pos:])
+		if match == "" {
+			return false
+		}
+
+		p.keep(p.pos + len(match))
+
+		if !p.parse(plural) || p.pos == len(p.message) {
+			return false
+		}
+
+		p.keep(p.pos + 1)
+	}
+}
+
+// skipArgument moves past a simple argument, which stays in the text.
+func (p *icuParser) skipArgument() bool {
+	depth := 0
+
+	for ; p.pos < len(p.message); p.pos++ {
+		switch p.message[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// keep ends the current text at pos and keeps the message from pos to end.
+func (p *icuParser) keep(end int) {
+	p.flush(p.pos)
+	p.parts = append(p.parts, part{raw: p.message[p.pos:end]})
+	p.pos, p.start = end, end
+}
+
+func (p *icuParser) flush(end int) {
+	if end > p.start {
+		p.parts = append(p.parts, part{raw: p.message[p.start:end], translate: true})
+	}
+
+	p.start = end
+}
@@ -0,0 +1,46 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICU(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		texts   []string
+	}{
+		{
+			name:    "simple arguments stay in the text",
+			message: "Hello {name}, welcome",
+			texts:   []string{"Hello {name}, welcome"},
+		},
+		{
+			name:    "plural with offset",
+			message: "{count, plural, offset:1 =0 {Nobody} one {You and # friend} other {You and # friends}}",
+			texts:   []string{"Nobody", "You and", "friend", "You and", "friends"},
+		},
+		{
+			name:    "select nested in plural",
+			message: "Sent {n, plural, one {{gender, select, female {her # file} other {their # file}}} other {# files}}.",
+			texts:   []string{"Sent", "her", "file", "their", "file", "files"},
+		},
+		{
+			name:    "malformed message is translated as a whole",
+			message: "{count, plural, one {# item}",
+			texts:   []string{"{count, plural, one {# item}"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.texts, translatable(t, tc.message, parseICU))
+		})
+	}
+}
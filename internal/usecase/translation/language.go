@@ -0,0 +1,171 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+// catalogue is the set of languages of the provider, by lowercase BCP-47 tag.
+type catalogue struct {
+	languages []entity.Language
+	byCode    map[string]entity.Language
+}
+
+func newCatalogue(languages []entity.Language) catalogue {
+	c := catalogue{languages: languages, byCode: make(map[string]entity.Language, len(languages))}
+
+	for _, l := range languages {
+		c.byCode[strings.ToLower(l.Code)] = l
+	}
+
+	return c
+}
+
+// lookup finds the language of a BCP-47 tag, ignoring case. A tag missing from the catalogue
+// matches its language subtag, so en-US is translated as en.
+func (c catalogue) lookup(code string) (entity.Language, bool) {
+	code = strings.ToLower(code)
+
+	if l, ok := c.byCode[code]; ok {
+		return l, true
+	}
+
+	l, ok := c.byCode[baseLanguage(code)]
+
+	return l, ok
+}
+
+// baseLanguage returns the lowercase language subtag of a BCP-47 tag, en for en-US.
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+
+	return base
+}
+
+// Languages returns the languages of the provider and the directions they are translated in.
+func (uc *UseCase) Languages(_ context.Context) (entity.LanguageList, error) {
+	return entity.LanguageList{Languages: slices.Clone(uc.catalogue.languages)}, nil
+}
+
+// DetectLanguage returns the language of the text detected by the provider; long texts are detected
+// from their first chunk and placeholders are left out. When the provider fails, the fallback detector,
+// if set, detects the language instead.
+func (uc *UseCase) DetectLanguage(ctx context.Context, text string) (entity.LanguageDetection, error) {
+	err := uc.checkLength("TranslationUseCase - DetectLanguage", "text", text)
+	if err != nil {
+		return entity.LanguageDetection{}, err
+	}
+
+	sample, _ := mask(text)
+	if !hasLetter(sample) {
+		return entity.LanguageDetection{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "text", "reason": "no letters"}),
+			errors.New("TranslationUseCase - DetectLanguage: text has no letters"),
+		)
+	}
+
+	if utf8.RuneCountInString(sample) > uc.chunkSize {
+		for _, c := range segment(sample, uc.chunkSize) {
+			if hasLetter(c.text) {
+				sample = c.text
+
+				break
+			}
+		}
+	}
+
+	detection, err := uc.webAPI.DetectLanguage(ctx, sample)
+	if err == nil {
+		return detection, nil
+	}
+
+	if uc.detector != nil {
+		fallback, fallbackErr := uc.detector.DetectLanguage(ctx, sample)
+		if fallbackErr == nil {
+			return fallback, nil
+		}
+	}
+
+	return entity.LanguageDetection{}, providerError("TranslationUseCase - DetectLanguage - s.webAPI.DetectLanguage", err)
+}
+
+// mergeDetections returns the language detected in most of the text of the results, with the confidence
+// of its detections weighted by their length, over the whole text. Results without detection count as disagreeing.
+func mergeDetections(results []entity.Translation) (string, float64) {
+	var (
+		languages []string
+		length    = map[string]int{}
+		weighted  = map[string]float64{}
+		total     int
+	)
+
+	for _, r := range results {
+		n := utf8.RuneCountInString(r.Original)
+		total += n
+
+		if r.DetectedLanguage == "" {
+			continue
+		}
+
+		if _, ok := length[r.DetectedLanguage]; !ok {
+			languages = append(languages, r.DetectedLanguage)
+		}
+
+		length[r.DetectedLanguage] += n
+		weighted[r.DetectedLanguage] += float64(n) * r.DetectionConfidence
+	}
+
+	if len(languages) == 0 || total == 0 {
+		return "", 0
+	}
+
+	best := languages[0]
+	for _, l := range languages[1:] {
+		if length[l] > length[best] {
+			best = l
+		}
+	}
+
+	return best, weighted[best] / float64(total)
+}
+
+// checkLanguages rejects a source or destination missing from the catalogue, or not supported
+// in that direction, with entity.ErrValidation naming the field. An empty catalogue accepts any language.
+func (uc *UseCase) checkLanguages(method, source, destination string) error {
+	if len(uc.catalogue.languages) == 0 {
+		return nil
+	}
+
+	fields := []struct {
+		name, code string
+		supported  func(entity.Language) bool
+	}{
+		{"source", source, func(l entity.Language) bool { return l.Source }},
+		{"destination", destination, func(l entity.Language) bool { return l.Destination }},
+	}
+
+	for _, field := range fields {
+		l, ok := uc.catalogue.lookup(field.code)
+		if ok && field.supported(l) {
+			continue
+		}
+
+		reason := "unsupported language"
+		if ok {
+			reason = "unsupported as " + field.name
+		}
+
+		return entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": field.name, "language": field.code, "reason": reason}),
+			fmt.Errorf("%s: %s %q: %s", method, field.name, field.code, reason),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package translation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// document is a markup input split into parts; concatenating the raw parts restores the input.
+// Only the text of translatable parts is sent to the provider, decoded, and its translation is encoded back.
+type document struct {
+	parts  []part
+	decode func(string) string
+	encode func(string) string
+}
+
+type part struct {
+	raw       string
+	translate bool
+}
+
+//nolint:gochecknoglobals // compiled once
+var (
+	_urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"']*[^\s<>()\[\]"'.,;:!?]`)
+
+	// _markdownLinePattern matches lines without text: thematic breaks, setext and table separators,
+	// link reference definitions.
+	_markdownLinePattern = regexp.MustCompile(`^(?:` +
+		` {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,}|=+[ \t]*)` +
+		`|[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*` +
+		`| {0,3}\[[^\]]+\]:[ \t]*\S+.*` +
+		`)\r?\n?$`)
+
+	// _markdownPrefixPattern matches block markers: blockquotes, headings, list items and task boxes.
+	_markdownPrefixPattern = regexp.MustCompile(`^(?:[ \t]*(?:>|#{1,6}(?:[ \t]|$)|(?:[-+*]|\d{1,9}[.)])(?:[ \t]|$)|\[[ xX]\](?:[ \t]|$)))*[ \t]*`)
+
+	// _markdownInlinePattern matches inline markup: escapes, autolinks, inline HTML, link brackets
+	// and destinations, emphasis and strikethrough markers, table cell separators, bare URLs.
+	// Underscore runs only count as markers at a word boundary, so snake_case words stay text.
+	_markdownInlinePattern = regexp.MustCompile(`\\[!-/:-@\[-` + "`" + `{-~]` +
+		`|<[a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*>` +
+		`|<!--.*?-->|</?[a-zA-Z][^<>]*>` +
+		`|!?\[` +
+		`|\]\([^()\s]*(?:\([^()\s]*\)[^()\s]*)?(?:\s+(?:"[^"]*"|'[^']*'))?\)` +
+		`|\]\[[^\]]*\]|\]` +
+		`|\*+|\b_+|_+\b|~~|\|` +
+		`|` + _urlPattern.String())
+
+	_fencePattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+
+	_htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\u00a0", "&nbsp;")
+)
+
+// _htmlProtectedElements hold code or scripts, their text is never translated.
+//
+//nolint:gochecknoglobals // lookup table
+var _htmlProtectedElements = map[string]bool{
+	"script": true, "style": true, "code": true, "pre": true, "kbd": true, "samp": true,
+}
+
+// parseHTML keeps tags, attributes, comments and the content of code elements, and makes text nodes
+// translatable, except URLs in them.
+func parseHTML(doc string) document {
+	var (
+		parts     []part
+		offset    int
+		protected int
+	)
+
+	z := html.NewTokenizer(strings.NewReader(doc))
+
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		raw := string(z.Raw())
+		offset += len(raw)
+
+		switch tokenType {
+		case html.TextToken:
+			if protected == 0 {
+				parts = append(parts, splitPattern(raw, _urlPattern)...)
+
+				continue
+			}
+		case html.StartTagToken:
+			if name, _ := z.TagName(); _htmlProtectedElements[string(name)] {
+				protected++
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); _htmlProtectedElements[string(name)] && protected > 0 {
+				protected--
+			}
+		case html.ErrorToken, html.SelfClosingTagToken, html.CommentToken, html.DoctypeToken:
+		}
+
+		parts = append(parts, part{raw: raw})
+	}
+
+	// Anything the tokenizer did not consume is kept as is.
+	if offset < len(doc) {
+		parts = append(parts, part{raw: doc[offset:]})
+	}
+
+	return document{
+		parts:  parts,
+		decode: html.UnescapeString,
+		encode: _htmlEscaper.Replace,
+	}
+}
+
+// parseMarkdown keeps code blocks and spans, block and inline markup, link destinations and URLs,
+// and makes the remaining text translatable. Lines are translated separately, so line breaks are kept.
+func parseMarkdown(doc string) document {
+	var (
+		parts []part
+		fence string
+	)
+
+	for _, line := range strings.SplitAfter(doc, "\n") {
+		if fence != "" {
+			parts = append(parts, part{raw: line})
+
+			if closesFence(line, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+
+		if match := _fencePattern.FindStringSubmatch(line); match != nil {
+			fence = match[1]
+			parts = append(parts, part{raw: line})
+
+			continue
+		}
+
+		if _markdownLinePattern.MatchString(line) {
+			parts = append(parts, part{raw: line})
+
+			continue
+		}
+
+		prefix := _markdownPrefixPattern.FindString(line)
+		parts = append(parts, part{raw: prefix})
+
+		for _, span := range splitCodeSpans(line[len(prefix):]) {
+			if !span.translate {
+				parts = append(parts, span)
+
+				continue
+			}
+
+			parts = append(parts, splitPattern(span.raw, _markdownInlinePattern)...)
+		}
+	}
+
+	return document{
+		parts:  parts,
+		decode: identity,
+		encode: identity,
+	}
+}
+
+// closesFence reports whether line closes a code block opened by fence: the same character, at least as long.
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// splitCodeSpans separates code spans, delimited by backtick strings of equal length, from the text.
+func splitCodeSpans(text string) []part {
+	var parts []part
+
+	start := 0
+
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+
+			continue
+		}
+
+		opener := backticks(text[i:])
+
+		closer := -1
+
+		for j := i + len(opener); j < len(text); {
+			if text[j] != '`' {
+				j++
+
+				continue
+			}
+
+			run := backticks(text[j:])
+			if len(run) == len(opener) {
+				closer = j
+
+				break
+			}
+
+			j += len(run)
+		}
+
+		if closer < 0 {
+			i += len(opener)
+
+			continue
+		}
+
+		end := closer + len(opener)
+		parts = append(parts, part{raw: text[start:i], translate: true}, part{raw: text[i:end]})
+		start, i = end, end
+	}
+
+	return append(parts, part{raw: text[start:], translate: true})
+}
+
+func backticks(text string) string {
+	return text[:len(text)-len(strings.TrimLeft(text, "`"))]
+}
+
+// splitPattern makes the matches of pattern untranslatable parts of text.
+func splitPattern(text string, pattern *regexp.Regexp) []part {
+	var parts []part
+
+	start := 0
+
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		parts = append(parts, part{raw: text[start:match[0]], translate: true}, part{raw: text[match[0]:match[1]]})
+		start = match[1]
+	}
+
+	return append(parts, part{raw: text[start:], translate: true})
+}
+
+// hasLetter reports whether text is worth translating.
+func hasLetter(text string) bool {
+	return strings.IndexFunc(text, unicode.IsLetter) >= 0
+}
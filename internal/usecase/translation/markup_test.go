@@ -0,0 +1,136 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// translatable returns the decoded texts sent to the provider and checks that the parts restore doc.
+func translatable(t *testing.T, doc string, parse func(string) document) []string {
+	t.Helper()
+
+	parsed := parse(doc)
+
+	var (
+		restored strings.Builder
+		texts    []string
+	)
+
+	for _, p := range parsed.parts {
+		restored.WriteString(p.raw)
+
+		text := parsed.decode(newChunk(p.raw).text)
+		if p.translate && hasLetter(text) {
+			texts = append(texts, text)
+		}
+	}
+
+	require.Equal(t, doc, restored.String())
+
+	return texts
+}
+
+func TestParseHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		doc   string
+		texts []string
+	}{
+		{
+			name:  "nested inline tags",
+			doc:   `<p class="intro">Hello <b>big <i>world</i></b>!</p>`,
+			texts: []string{"Hello", "big", "world"},
+		},
+		{
+			name:  "entities are decoded",
+			doc:   "<p>Fish &amp; chips &lt;3&nbsp;you</p>",
+			texts: []string{"Fish & chips <3 you"},
+		},
+		{
+			name:  "attributes and URLs are kept",
+			doc:   `<a href="https://example.com" title="Home">Open https://example.com/docs now</a>`,
+			texts: []string{"Open", "now"},
+		},
+		{
+			name:  "code and scripts are kept",
+			doc:   "<p>Run <code>go test</code></p><pre>make\nlint</pre><script>var text = 'a';</script>",
+			texts: []string{"Run"},
+		},
+		{
+			name:  "comments and doctype are kept",
+			doc:   "<!DOCTYPE html><!-- header --><h1>Title</h1>",
+			texts: []string{"Title"},
+		},
+		{
+			name:  "unterminated tag",
+			doc:   "Text <b",
+			texts: []string{"Text"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.texts, translatable(t, tc.doc, parseHTML))
+		})
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		doc   string
+		texts []string
+	}{
+		{
+			name:  "block markers",
+			doc:   "# Title\n\n> Quote\n\n- [x] Done\n1. First\n",
+			texts: []string{"Title", "Quote", "Done", "First"},
+		},
+		{
+			name:  "fenced code blocks",
+			doc:   "Before\n\n```go\n// comment\nfmt.Println(\"hi\")\n```\n\n~~~~\ntext\n~~~\nstill code\n~~~~\nAfter\n",
+			texts: []string{"Before", "After"},
+		},
+		{
+			name:  "code spans",
+			doc:   "Use `go test` or ``a ` b`` here, not `this",
+			texts: []string{"Use", "or", "here, not `this"},
+		},
+		{
+			name:  "nested inline markup",
+			doc:   "Read **the [full *guide*](https://example.com/a_(b) \"Guide\")** first.",
+			texts: []string{"Read", "the", "full", "guide", "first."},
+		},
+		{
+			name:  "underscore emphasis",
+			doc:   "Some _emphasis_ and __strong__ text, but snake_case stays.",
+			texts: []string{"Some", "emphasis", "and", "strong", "text, but snake_case stays."},
+		},
+		{
+			name:  "links, images, inline HTML and URLs",
+			doc:   "![Logo](logo.png) see <https://example.com>, <br/>https://example.com/x or [ref][1].\n\n[1]: https://example.com\n",
+			texts: []string{"Logo", "see", "or", "ref"},
+		},
+		{
+			name:  "tables and thematic breaks",
+			doc:   "| Name | Value |\n|:-----|------:|\n| Key | Text |\n\n---\n",
+			texts: []string{"Name", "Value", "Key", "Text"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.texts, translatable(t, tc.doc, parseMarkdown))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package translation
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	_scopeLocal  = "local"
+	_scopeRemote = "remote"
+)
+
+type metrics struct {
+	coalesced *prometheus.CounterVec
+}
+
+// newMetrics returns unregistered collectors if reg is nil.
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		coalesced: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "translation_coalesced_total",
+			Help: "Translate requests served by the provider call of an identical request: local in this instance, remote in another one.",
+		}, []string{"scope"}),
+	}
+
+	if reg != nil {
+		reg.MustRegister(m.coalesced)
+	}
+
+	return m
+}
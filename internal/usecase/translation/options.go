@@ -0,0 +1,74 @@
+package translation
+
+import (
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/evrone/go-clean-template/internal/repo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option -.
+type Option func(*UseCase)
+
+// Coalescer shares provider calls with other instances, e.g. through a Redis lock.
+func Coalescer(coalescer repo.TranslationCoalescer) Option {
+	return func(uc *UseCase) {
+		uc.coalescer = coalescer
+	}
+}
+
+// Glossaries makes Translate enforce the glossary entries of the language pair stored in glossaries.
+func Glossaries(glossaries repo.GlossaryRepo) Option {
+	return func(uc *UseCase) {
+		uc.glossaries = glossaries
+	}
+}
+
+// DetectionFallback detects languages with detector when the provider fails to, e.g. a local n-gram detector.
+func DetectionFallback(detector repo.LanguageDetector) Option {
+	return func(uc *UseCase) {
+		uc.detector = detector
+	}
+}
+
+// Metrics registers the use case metrics in reg; registering twice in the same registry panics.
+func Metrics(reg prometheus.Registerer) Option {
+	return func(uc *UseCase) {
+		uc.metrics = newMetrics(reg)
+	}
+}
+
+// MaxInputLength rejects texts longer than length characters with entity.ErrValidation. Zero disables the check.
+func MaxInputLength(length int) Option {
+	return func(uc *UseCase) {
+		uc.maxInputLength = length
+	}
+}
+
+// ChunkSize sets the maximum characters sent to the provider in one call; longer texts are split into chunks.
+func ChunkSize(size int) Option {
+	return func(uc *UseCase) {
+		uc.chunkSize = size
+	}
+}
+
+// ChunkConcurrency limits the chunks of one text translated at the same time.
+func ChunkConcurrency(concurrency int) Option {
+	return func(uc *UseCase) {
+		uc.chunkConcurrency = concurrency
+	}
+}
+
+// FileConcurrency limits the values of one resource file translated at the same time.
+func FileConcurrency(concurrency int) Option {
+	return func(uc *UseCase) {
+		uc.fileConcurrency = concurrency
+	}
+}
+
+// Languages sets the languages of the provider; sources and destinations outside of them
+// are rejected with entity.ErrValidation. Without languages any code is sent to the provider.
+func Languages(languages []entity.Language) Option {
+	return func(uc *UseCase) {
+		uc.catalogue = newCatalogue(languages)
+	}
+}
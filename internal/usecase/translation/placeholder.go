@@ -0,0 +1,62 @@
+package translation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+)
+
+//nolint:gochecknoglobals // compiled once
+var (
+	// _placeholderPattern matches {{handlebars}}, ICU {arguments} and printf verbs, in this order.
+	_placeholderPattern = regexp.MustCompile(`\{\{\{?[^{}]+\}?\}\}` +
+		`|\{\s*(?:\d+|[A-Za-z_][\w.-]*)\s*(?:,\s*(?:number|date|time|duration|ordinal|spellout)\s*(?:,[^{}]*)?)?\}` +
+		`|%(?:\d+\$)?[-+#0]*(?:\d+|\*)?(?:\.(?:\d+|\*))?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcspqvTb@%]`)
+
+	// _tokenPattern matches the tokens replacing placeholders, with the spaces providers may add inside.
+	_tokenPattern = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+)
+
+// mask replaces the placeholders of text with numbered tokens, which providers keep as they are,
+// and returns the placeholders by token number.
+func mask(text string) (string, []string) {
+	var placeholders []string
+
+	masked := _placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		placeholders = append(placeholders, placeholder)
+
+		return "⟦" + strconv.Itoa(len(placeholders)-1) + "⟧"
+	})
+
+	return masked, placeholders
+}
+
+// unmask puts the placeholders back in place of their tokens, wherever the provider moved them,
+// and fails with entity.ErrPlaceholderLost if a token is missing from the translation.
+func unmask(text string, placeholders []string) (string, error) {
+	found := make([]bool, len(placeholders))
+
+	restored := _tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		i, err := strconv.Atoi(_tokenPattern.FindStringSubmatch(token)[1])
+		if err != nil || i >= len(placeholders) {
+			return token
+		}
+
+		found[i] = true
+
+		return placeholders[i]
+	})
+
+	for i, ok := range found {
+		if !ok {
+			return "", entity.NewAppError(
+				entity.ErrPlaceholderLost.WithDetails(map[string]string{"placeholder": placeholders[i]}),
+				fmt.Errorf("TranslationUseCase - unmask: placeholder %q missing from %q", placeholders[i], text),
+			)
+		}
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,79 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/evrone/go-clean-template/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		text         string
+		masked       string
+		placeholders []string
+	}{
+		{
+			name:         "ICU arguments",
+			text:         "Hello {name}, today is {0} and {day, date, short}",
+			masked:       "Hello ⟦0⟧, today is ⟦1⟧ and ⟦2⟧",
+			placeholders: []string{"{name}", "{0}", "{day, date, short}"},
+		},
+		{
+			name:         "printf verbs",
+			text:         "%s has %d messages, %1$s: %5.2f%%",
+			masked:       "⟦0⟧ has ⟦1⟧ messages, ⟦2⟧: ⟦3⟧⟦4⟧",
+			placeholders: []string{"%s", "%d", "%1$s", "%5.2f", "%%"},
+		},
+		{
+			name:         "handlebars",
+			text:         "Hi {{user.name}}, {{{html}}}",
+			masked:       "Hi ⟦0⟧, ⟦1⟧",
+			placeholders: []string{"{{user.name}}", "{{{html}}}"},
+		},
+		{
+			name:   "plain text",
+			text:   "50% off {not a placeholder}",
+			masked: "50% off {not a placeholder}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			masked, placeholders := mask(tc.text)
+			require.Equal(t, tc.masked, masked)
+			require.Equal(t, tc.placeholders, placeholders)
+
+			restored, err := unmask(masked, placeholders)
+			require.NoError(t, err)
+			require.Equal(t, tc.text, restored)
+		})
+	}
+}
+
+func TestUnmask(t *testing.T) {
+	t.Parallel()
+
+	placeholders := []string{"{name}", "%d"}
+
+	t.Run("reordered and spaced tokens", func(t *testing.T) {
+		t.Parallel()
+
+		restored, err := unmask("⟦ 1 ⟧ tin nhắn cho ⟦0⟧", placeholders)
+		require.NoError(t, err)
+		require.Equal(t, "%d tin nhắn cho {name}", restored)
+	})
+
+	t.Run("lost placeholder", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := unmask("tin nhắn cho ⟦0⟧", placeholders)
+		require.ErrorIs(t, err, entity.ErrPlaceholderLost)
+		require.Equal(t, "%d", entity.GetAppError(err).Details["placeholder"])
+	})
+}
@@ -0,0 +1,113 @@
+package translation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// chunk is a part of the text sent to the provider alone. The surrounding whitespace is kept
+// out of the request, providers trim it, and put back around the translation.
+type chunk struct {
+	leading  string
+	text     string
+	trailing string
+}
+
+// segment splits text into chunks of at most limit runes, without the surrounding whitespace.
+// Chunks end at Unicode sentence boundaries (UAX #29), preferring paragraph breaks; a sentence longer than
+// limit is split between words. Concatenating leading+text+trailing of all chunks restores text.
+func segment(text string, limit int) []chunk {
+	var (
+		chunks  []chunk
+		current strings.Builder
+		length  int
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, newChunk(current.String()))
+			current.Reset()
+			length = 0
+		}
+	}
+
+	state := -1
+
+	for text != "" {
+		var sentence string
+
+		sentence, text, state = uniseg.FirstSentenceInString(text, state)
+
+		sentenceLength := utf8.RuneCountInString(strings.TrimSpace(sentence))
+
+		// A paragraph break ends the chunk once it is half full, so chunks follow the document structure.
+		if length > 0 && (length+sentenceLength > limit || length >= limit/2 && endsParagraph(current.String())) {
+			flush()
+		}
+
+		if sentenceLength > limit {
+			for _, part := range splitWords(sentence, limit) {
+				chunks = append(chunks, newChunk(part))
+			}
+
+			continue
+		}
+
+		current.WriteString(sentence)
+		length += utf8.RuneCountInString(sentence)
+	}
+
+	flush()
+
+	return chunks
+}
+
+// splitWords splits a long sentence at the last space before limit runes, or at limit runes without one.
+func splitWords(sentence string, limit int) []string {
+	var parts []string
+
+	for utf8.RuneCountInString(sentence) > limit {
+		cut, runes, lastSpace := 0, 0, -1
+
+		for i, r := range sentence {
+			if runes == limit {
+				cut = i
+
+				break
+			}
+
+			if unicode.IsSpace(r) {
+				lastSpace = i + utf8.RuneLen(r)
+			}
+
+			runes++
+		}
+
+		if lastSpace > 0 {
+			cut = lastSpace
+		}
+
+		parts = append(parts, sentence[:cut])
+		sentence = sentence[cut:]
+	}
+
+	return append(parts, sentence)
+}
+
+// endsParagraph reports whether text ends with a blank line.
+func endsParagraph(text string) bool {
+	trailing := text[len(strings.TrimRightFunc(text, unicode.IsSpace)):]
+
+	return strings.Count(trailing, "\n") >= 2 //nolint:mnd // line break of the last line and the blank line
+}
+
+func newChunk(text string) chunk {
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	leading := text[:len(text)-len(trimmed)]
+	body := strings.TrimRightFunc(trimmed, unicode.IsSpace)
+
+	return chunk{leading: leading, text: body, trailing: trimmed[len(body):]}
+}
@@ -0,0 +1,98 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		text   string
+		limit  int
+		chunks []string
+	}{
+		{
+			name:   "short text is one chunk",
+			text:   "Hello world. How are you?",
+			limit:  100,
+			chunks: []string{"Hello world. How are you?"},
+		},
+		{
+			name:   "sentences over the limit are split",
+			text:   "First sentence. Second sentence. Third one.",
+			limit:  20,
+			chunks: []string{"First sentence.", "Second sentence.", "Third one."},
+		},
+		{
+			name:   "paragraph break ends a half full chunk",
+			text:   "One two three.\n\nFour. Five.",
+			limit:  25,
+			chunks: []string{"One two three.", "Four. Five."},
+		},
+		{
+			name:   "long sentence is split between words",
+			text:   "alpha beta gamma delta epsilon",
+			limit:  12,
+			chunks: []string{"alpha beta", "gamma delta", "epsilon"},
+		},
+		{
+			name:   "word longer than the limit is cut",
+			text:   "abcdefghij",
+			limit:  4,
+			chunks: []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:   "runes are counted, not bytes",
+			text:   "Привет мир. Как дела?",
+			limit:  11,
+			chunks: []string{"Привет мир.", "Как дела?"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			chunks := segment(tc.text, tc.limit)
+
+			texts := make([]string, 0, len(chunks))
+
+			var restored strings.Builder
+
+			for _, c := range chunks {
+				texts = append(texts, c.text)
+				restored.WriteString(c.leading + c.text + c.trailing)
+
+				require.LessOrEqual(t, utf8.RuneCountInString(c.text), tc.limit)
+			}
+
+			require.Equal(t, tc.chunks, texts)
+			require.Equal(t, tc.text, restored.String())
+		})
+	}
+}
+
+func TestSegmentKeepsWhitespace(t *testing.T) {
+	t.Parallel()
+
+	text := "  Title\n\n\tFirst paragraph.\r\n\r\nSecond paragraph.  \n"
+
+	chunks := segment(text, 20)
+
+	var restored strings.Builder
+
+	for _, c := range chunks {
+		require.Equal(t, strings.TrimSpace(c.text), c.text)
+		restored.WriteString(c.leading + c.text + c.trailing)
+	}
+
+	require.Equal(t, text, restored.String())
+	require.Equal(t, "  ", chunks[0].leading)
+	require.Equal(t, "  \n", chunks[len(chunks)-1].trailing)
+}
@@ -2,26 +2,63 @@ package translation
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/evrone/go-clean-template/internal/entity"
 	"github.com/evrone/go-clean-template/internal/repo"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/text/unicode/norm"
 )
 
-const _defaultHistoryLimit = 100
+const (
+	_defaultHistoryLimit     = 100
+	_defaultChunkSize        = 4500
+	_defaultChunkConcurrency = 4
+	_defaultFileConcurrency  = 8
+)
 
 // UseCase -.
 type UseCase struct {
-	repo   repo.TranslationRepo
-	webAPI repo.TranslationWebAPI
+	repo       repo.TranslationRepo
+	webAPI     repo.TranslationWebAPI
+	coalescer  repo.TranslationCoalescer
+	detector   repo.LanguageDetector
+	glossaries repo.GlossaryRepo
+	metrics    *metrics
+	catalogue  catalogue
+
+	maxInputLength   int
+	chunkSize        int
+	chunkConcurrency int
+	fileConcurrency  int
+
+	group singleflight.Group
 }
 
 // New -.
-func New(r repo.TranslationRepo, w repo.TranslationWebAPI) *UseCase {
-	return &UseCase{
-		repo:   r,
-		webAPI: w,
+func New(r repo.TranslationRepo, w repo.TranslationWebAPI, opts ...Option) *UseCase {
+	uc := &UseCase{
+		repo:             r,
+		webAPI:           w,
+		metrics:          newMetrics(nil),
+		chunkSize:        _defaultChunkSize,
+		chunkConcurrency: _defaultChunkConcurrency,
+		fileConcurrency:  _defaultFileConcurrency,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(uc)
 	}
+
+	return uc
 }
 
 // History - getting translate history from store.
@@ -35,10 +72,42 @@ func (uc *UseCase) History(ctx context.Context) (entity.TranslationHistory, erro
 }
 
 // Translate -.
+// Sources and destinations missing from the provider languages, when they are set, are rejected.
+// Placeholders such as {name}, %s and {{count}} are not sent to the provider and are put back in the translation.
+// HTML and Markdown inputs are translated text node by text node, keeping the markup as is.
+// Texts longer than the chunk size are translated in chunks, concurrently, and stored as one history entry.
+// Concurrent identical requests share one provider call; each request is still stored in the history.
+// Terms of the glossaries of the language pair are not sent to the provider: they are put back
+// as their glossary translation, or as they are for do-not-translate entries, and the entries are returned.
+// With an auto source, the glossaries of the detected language apply.
 func (uc *UseCase) Translate(ctx context.Context, t entity.Translation) (entity.Translation, error) {
-	translation, err := uc.webAPI.Translate(ctx, t)
+	err := uc.checkLanguages("TranslationUseCase - Translate", t.Source, t.Destination)
 	if err != nil {
-		return entity.Translation{}, entity.NewAppError(entity.ErrExternalService, fmt.Errorf("TranslationUseCase - Translate - s.webAPI.Translate: %w", err))
+		return entity.Translation{}, err
+	}
+
+	err = uc.checkLength("TranslationUseCase - Translate", "original", t.Original)
+	if err != nil {
+		return entity.Translation{}, err
+	}
+
+	switch t.Format {
+	case "", entity.FormatText, entity.FormatHTML, entity.FormatMarkdown:
+	default:
+		return entity.Translation{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "format"}),
+			fmt.Errorf("TranslationUseCase - Translate: unknown format %q", t.Format),
+		)
+	}
+
+	g, err := uc.glossary(ctx, "TranslationUseCase - Translate", t)
+	if err != nil {
+		return entity.Translation{}, err
+	}
+
+	translation, err := uc.translateDocument(ctx, t, g)
+	if err != nil {
+		return entity.Translation{}, providerError("TranslationUseCase - Translate - s.webAPI.Translate", err)
 	}
 
 	err = uc.repo.Store(ctx, translation)
@@ -48,3 +117,385 @@ func (uc *UseCase) Translate(ctx context.Context, t entity.Translation) (entity.
 
 	return translation, nil
 }
+
+// TranslateFile translates the values of a resource file and returns it in the same format,
+// with its keys, comments and plural forms. Values are translated concurrently, up to the file concurrency,
+// and are not stored in the history. Subtitle cues are sent in batches of lines up to the chunk size.
+func (uc *UseCase) TranslateFile(ctx context.Context, file entity.TranslationFile) (entity.TranslationFile, error) {
+	err := uc.checkLanguages("TranslationUseCase - TranslateFile", file.Source, file.Destination)
+	if err != nil {
+		return entity.TranslationFile{}, err
+	}
+
+	switch file.Format {
+	case entity.FileFormatJSON, entity.FileFormatYAML, entity.FileFormatPO, entity.FileFormatXLIFF,
+		entity.FileFormatSRT, entity.FileFormatVTT:
+	default:
+		return entity.TranslationFile{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(map[string]string{"field": "format"}),
+			fmt.Errorf("TranslationUseCase - TranslateFile: unknown format %q", file.Format),
+		)
+	}
+
+	res, err := parseResource(file.Format, file.Content)
+	if err != nil {
+		details := map[string]string{"field": "file", "reason": err.Error()}
+
+		var errs lineErrors
+		if errors.As(err, &errs) {
+			details["line"] = strconv.Itoa(errs[0].line)
+		}
+
+		return entity.TranslationFile{}, entity.NewAppError(
+			entity.ErrValidation.WithDetails(details),
+			fmt.Errorf("TranslationUseCase - TranslateFile - parseResource: %w", err),
+		)
+	}
+
+	translations := make([]string, len(res.units))
+
+	for i, u := range res.units {
+		err = uc.checkLength("TranslationUseCase - TranslateFile", "file", u.text)
+		if err != nil {
+			return entity.TranslationFile{}, err
+		}
+
+		translations[i] = u.text
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(uc.fileConcurrency)
+
+	for _, batch := range res.batches(uc.chunkSize) {
+		group.Go(func() error {
+			return uc.translateUnits(groupCtx, file, res.units, batch, translations)
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		return entity.TranslationFile{}, providerError("TranslationUseCase - TranslateFile - s.webAPI.Translate", err)
+	}
+
+	file.Content, err = res.encode(translations, file.Destination)
+	if err != nil {
+		return entity.TranslationFile{}, entity.NewAppError(entity.ErrInternal, fmt.Errorf("TranslationUseCase - TranslateFile - res.encode: %w", err))
+	}
+
+	return file, nil
+}
+
+// translateUnits translates a batch of units as one text, a unit per line, into translations.
+// The units are translated one by one if the provider does not keep the lines.
+func (uc *UseCase) translateUnits(
+	ctx context.Context,
+	file entity.TranslationFile,
+	units []unit,
+	batch []int,
+	translations []string,
+) error {
+	if len(batch) > 1 {
+		texts := make([]string, 0, len(batch))
+		for _, i := range batch {
+			texts = append(texts, units[i].text)
+		}
+
+		translation, err := uc.translateUnit(ctx, file, unit{text: strings.Join(texts, "\n"), parse: units[batch[0]].parse})
+		if err != nil {
+			return fmt.Errorf("values %d-%d: %w", batch[0], batch[len(batch)-1], err)
+		}
+
+		lines := strings.Split(translation, "\n")
+		if len(lines) == len(batch) {
+			for j, i := range batch {
+				translations[i] = lines[j]
+			}
+
+			return nil
+		}
+	}
+
+	for _, i := range batch {
+		translation, err := uc.translateUnit(ctx, file, units[i])
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+
+		translations[i] = translation
+	}
+
+	return nil
+}
+
+func (uc *UseCase) translateUnit(ctx context.Context, file entity.TranslationFile, u unit) (string, error) {
+	t := entity.Translation{Source: file.Source, Destination: file.Destination, Original: u.text}
+
+	translation, err := uc.translateParts(ctx, t, u.parse(u.text), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return translation.Translation, nil
+}
+
+// translateDocument translates the text nodes of HTML and Markdown inputs and puts them back
+// between the untouched markup.
+func (uc *UseCase) translateDocument(ctx context.Context, t entity.Translation, g *glossary) (entity.Translation, error) {
+	var doc document
+
+	switch t.Format {
+	case entity.FormatHTML:
+		doc = parseHTML(t.Original)
+	case entity.FormatMarkdown:
+		doc = parseMarkdown(t.Original)
+	default:
+		return uc.translateChunks(ctx, t, g, uc.chunkConcurrency)
+	}
+
+	return uc.translateParts(ctx, t, doc, g)
+}
+
+// translateParts translates the translatable parts of doc concurrently and joins all the parts.
+// The chunks of a long part are translated one after another, so provider calls stay within the chunk concurrency.
+func (uc *UseCase) translateParts(
+	ctx context.Context,
+	t entity.Translation,
+	doc document,
+	g *glossary,
+) (entity.Translation, error) {
+	translated := make([]string, len(doc.parts))
+	results := make([]entity.Translation, len(doc.parts))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(uc.chunkConcurrency)
+
+	for i, p := range doc.parts {
+		translated[i] = p.raw
+
+		node := newChunk(p.raw)
+		text := doc.decode(node.text)
+
+		if !p.translate || !hasLetter(text) {
+			continue
+		}
+
+		group.Go(func() error {
+			plain := t
+			plain.Format = ""
+			plain.Original = text
+
+			result, err := uc.translateChunks(groupCtx, plain, g, 1)
+			if err != nil {
+				return fmt.Errorf("text node %d: %w", i, err)
+			}
+
+			translated[i] = node.leading + doc.encode(result.Translation) + node.trailing
+			results[i] = result
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return entity.Translation{}, err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	t.Translation = strings.Join(translated, "")
+	t.DetectedLanguage, t.DetectionConfidence = mergeDetections(results)
+	t.GlossaryEntries = mergeGlossaryEntries(results)
+
+	return t, nil
+}
+
+// translateChunks splits a long text at sentence boundaries, translates up to concurrency chunks at once
+// and joins the translated chunks with the original whitespace and line breaks.
+func (uc *UseCase) translateChunks(
+	ctx context.Context,
+	t entity.Translation,
+	g *glossary,
+	concurrency int,
+) (entity.Translation, error) {
+	if utf8.RuneCountInString(t.Original) <= uc.chunkSize {
+		return uc.translate(ctx, t, g)
+	}
+
+	chunks := segment(t.Original, uc.chunkSize)
+	results := make([]entity.Translation, len(chunks))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+
+	for i, c := range chunks {
+		if c.text == "" {
+			continue
+		}
+
+		group.Go(func() error {
+			part := t
+			part.Original = c.text
+
+			result, err := uc.translate(groupCtx, part, g)
+			if err != nil {
+				return fmt.Errorf("chunk %d: %w", i, err)
+			}
+
+			results[i] = result
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return entity.Translation{}, err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	var text strings.Builder
+	for i, c := range chunks {
+		text.WriteString(c.leading)
+		text.WriteString(results[i].Translation)
+		text.WriteString(c.trailing)
+	}
+
+	t.Translation = text.String()
+	t.DetectedLanguage, t.DetectionConfidence = mergeDetections(results)
+	t.GlossaryEntries = mergeGlossaryEntries(results)
+
+	return t, nil
+}
+
+// translate joins an in-flight provider call for the same request or starts one.
+// The call outlives a canceled caller, so the other waiters still get the result, but keeps its deadline.
+func (uc *UseCase) translate(ctx context.Context, t entity.Translation, g *glossary) (entity.Translation, error) {
+	key := requestKey(t, g)
+	leader := false
+
+	ch := uc.group.DoChan(key, func() (interface{}, error) {
+		leader = true
+
+		callCtx, cancel := detach(ctx)
+		defer cancel()
+
+		if uc.coalescer == nil {
+			return uc.callProvider(callCtx, t, g)
+		}
+
+		translation, shared, err := uc.coalescer.Coalesce(callCtx, key, func(ctx context.Context) (entity.Translation, error) {
+			return uc.callProvider(ctx, t, g)
+		})
+		if shared {
+			uc.metrics.coalesced.WithLabelValues(_scopeRemote).Inc()
+		}
+
+		return translation, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return entity.Translation{}, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return entity.Translation{}, result.Err
+		}
+
+		if !leader {
+			uc.metrics.coalesced.WithLabelValues(_scopeLocal).Inc()
+		}
+
+		translated, _ := result.Val.(entity.Translation)
+
+		// Keep the fields of this request, the shared result may differ in letter case.
+		t.Translation = translated.Translation
+		t.DetectedLanguage, t.DetectionConfidence = translated.DetectedLanguage, translated.DetectionConfidence
+		t.GlossaryEntries = translated.GlossaryEntries
+
+		return t, nil
+	}
+}
+
+// checkLength rejects texts longer than the maximum input length with entity.ErrValidation naming field.
+func (uc *UseCase) checkLength(method, field, text string) error {
+	length := utf8.RuneCountInString(text)
+	if uc.maxInputLength == 0 || length <= uc.maxInputLength {
+		return nil
+	}
+
+	return entity.NewAppError(
+		entity.ErrValidation.WithDetails(map[string]string{
+			"field":      field,
+			"max_length": strconv.Itoa(uc.maxInputLength),
+		}),
+		fmt.Errorf("%s: %s is %d characters, maximum %d", method, field, length, uc.maxInputLength),
+	)
+}
+
+// providerError passes errors the client can act on through and reports the others as entity.ErrExternalService.
+func providerError(method string, err error) error {
+	if errors.Is(err, entity.ErrProviderUnavailable) || errors.Is(err, entity.ErrPlaceholderLost) {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	return entity.NewAppError(entity.ErrExternalService, fmt.Errorf("%s: %w", method, err))
+}
+
+// callProvider hides the placeholders and the glossary terms of the text from the provider,
+// which would translate or break them.
+func (uc *UseCase) callProvider(ctx context.Context, t entity.Translation, g *glossary) (entity.Translation, error) {
+	masked, placeholders := mask(t.Original)
+	masked, placeholders, applied := g.mask(masked, placeholders)
+
+	if len(placeholders) == 0 {
+		return uc.webAPI.Translate(ctx, t) //nolint:wrapcheck // wrapped by the caller
+	}
+
+	request := t
+	request.Original = masked
+
+	translation, err := uc.webAPI.Translate(ctx, request)
+	if err != nil {
+		return entity.Translation{}, err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	translation.Original = t.Original
+
+	translation.Translation, err = unmask(translation.Translation, placeholders)
+	if err != nil {
+		return entity.Translation{}, err
+	}
+
+	translation.GlossaryEntries = applied
+
+	return translation, nil
+}
+
+// requestKey identifies requests with the same result: language codes are case-insensitive
+// and the text is compared in Unicode normalization form C. Requests with different glossaries differ.
+func requestKey(t entity.Translation, g *glossary) string {
+	hash := sha256.New()
+
+	if g != nil {
+		hash.Write([]byte(g.key))
+	}
+
+	for _, part := range []string{
+		strings.ToLower(strings.TrimSpace(t.Source)),
+		strings.ToLower(strings.TrimSpace(t.Destination)),
+		norm.NFC.String(t.Original),
+	} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+
+	return context.WithCancel(detached)
+}
@@ -3,10 +3,18 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"testing/synctest"
+	"time"
 
 	"github.com/evrone/go-clean-template/internal/entity"
 	"github.com/evrone/go-clean-template/internal/usecase/translation"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -135,7 +143,7 @@ func TestTranslate(t *testing.T) { //nolint:tparallel // data races here
 		{
 			name: "success",
 			mock: func() {
-				webAPI.EXPECT().Translate(context.Background(), inputTranslation).Return(translatedResult, nil)
+				webAPI.EXPECT().Translate(gomock.Any(), inputTranslation).Return(translatedResult, nil)
 				repo.EXPECT().Store(context.Background(), translatedResult).Return(nil)
 			},
 			res: translatedResult,
@@ -144,7 +152,7 @@ func TestTranslate(t *testing.T) { //nolint:tparallel // data races here
 		{
 			name: "web API error",
 			mock: func() {
-				webAPI.EXPECT().Translate(context.Background(), inputTranslation).Return(entity.Translation{}, errInternalServErr)
+				webAPI.EXPECT().Translate(gomock.Any(), inputTranslation).Return(entity.Translation{}, errInternalServErr)
 			},
 			res: entity.Translation{},
 			err: true,
@@ -152,7 +160,7 @@ func TestTranslate(t *testing.T) { //nolint:tparallel // data races here
 		{
 			name: "repo store error",
 			mock: func() {
-				webAPI.EXPECT().Translate(context.Background(), inputTranslation).Return(translatedResult, nil)
+				webAPI.EXPECT().Translate(gomock.Any(), inputTranslation).Return(translatedResult, nil)
 				repo.EXPECT().Store(context.Background(), translatedResult).Return(errInternalServErr)
 			},
 			res: entity.Translation{},
@@ -161,7 +169,7 @@ func TestTranslate(t *testing.T) { //nolint:tparallel // data races here
 		{
 			name: "empty input translation",
 			mock: func() {
-				webAPI.EXPECT().Translate(context.Background(), entity.Translation{}).Return(entity.Translation{}, nil)
+				webAPI.EXPECT().Translate(gomock.Any(), entity.Translation{}).Return(entity.Translation{}, nil)
 				repo.EXPECT().Store(context.Background(), entity.Translation{}).Return(nil)
 			},
 			res: entity.Translation{},
@@ -211,6 +219,19 @@ func TestTranslateAppError(t *testing.T) {
 		appErr := entity.GetAppError(err)
 		require.Equal(t, entity.ErrExternalService.Code, appErr.Code)
 	})
+
+	t.Run("provider unavailable is passed through", func(t *testing.T) {
+		unavailable := entity.ErrProviderUnavailable.WithDetails(map[string]string{entity.DetailRetryAfter: "30"})
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(entity.Translation{}, unavailable)
+
+		_, err := translationUseCase.Translate(context.Background(), entity.Translation{})
+
+		require.Error(t, err)
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrProviderUnavailable.Code, appErr.Code)
+		require.Equal(t, "30", appErr.Details[entity.DetailRetryAfter])
+	})
 }
 
 func TestTranslateRepoAppError(t *testing.T) {
@@ -230,3 +251,710 @@ func TestTranslateRepoAppError(t *testing.T) {
 		require.Equal(t, entity.ErrInternal.Code, appErr.Code)
 	})
 }
+
+func TestTranslateCoalescesConcurrentDuplicates(t *testing.T) {
+	t.Parallel()
+
+	// In the bubble, synctest.Wait returns once every request waits: the first one for the provider,
+	// the duplicates for its call.
+	synctest.Test(t, func(t *testing.T) {
+		mockCtl := gomock.NewController(t)
+		repo := NewMockTranslationRepo(mockCtl)
+		webAPI := NewMockTranslationWebAPI(mockCtl)
+		reg := prometheus.NewRegistry()
+		useCase := translation.New(repo, webAPI, translation.Metrics(reg))
+
+		const requests = 5
+
+		release := make(chan struct{})
+
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+				<-release
+
+				t.Translation = "text"
+
+				return t, nil
+			},
+		)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(requests).Return(nil)
+
+		var wg sync.WaitGroup
+
+		results := make([]entity.Translation, requests)
+
+		for i := range requests {
+			// Language codes differ only in case, the requests are identical.
+			source := "en"
+			if i%2 == 1 {
+				source = "EN"
+			}
+
+			wg.Go(func() {
+				result, err := useCase.Translate(t.Context(), entity.Translation{Source: source, Destination: "vi", Original: "text"})
+				assert.NoError(t, err)
+
+				results[i] = result
+			})
+		}
+
+		synctest.Wait()
+		close(release)
+		wg.Wait()
+
+		for i, result := range results {
+			require.Equal(t, "text", result.Translation)
+			require.Equal(t, i%2 == 1, result.Source == "EN", "each request keeps its own fields")
+		}
+
+		requireCoalesced(t, reg, "local", requests-1)
+	})
+}
+
+func TestTranslateCallerCancelDoesNotCancelSharedCall(t *testing.T) {
+	t.Parallel()
+
+	translationUseCase, _, webAPI := translationUseCase(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	release := make(chan struct{})
+	done := make(chan error, 1)
+
+	webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, t entity.Translation) (entity.Translation, error) {
+			// The caller gives up while the provider call is in flight.
+			cancel()
+			<-release
+
+			done <- ctx.Err()
+
+			return t, nil
+		},
+	)
+
+	_, err := translationUseCase.Translate(ctx, entity.Translation{Original: "text"})
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-done, "the provider call is not canceled with the first caller")
+}
+
+func TestTranslateRemoteCoalescing(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	coalescer := NewMockTranslationCoalescer(mockCtl)
+	reg := prometheus.NewRegistry()
+	useCase := translation.New(repo, webAPI, translation.Coalescer(coalescer), translation.Metrics(reg))
+
+	coalescer.EXPECT().Coalesce(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Translation{Translation: "text"}, true, nil)
+	repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := useCase.Translate(t.Context(), entity.Translation{Source: "en", Destination: "vi", Original: "text"})
+	require.NoError(t, err)
+	require.Equal(t, "text", result.Translation)
+	requireCoalesced(t, reg, "remote", 1)
+}
+
+func requireCoalesced(t *testing.T, reg *prometheus.Registry, scope string, count int) {
+	t.Helper()
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(fmt.Sprintf(`
+# HELP translation_coalesced_total Translate requests served by the provider call of an identical request: local in this instance, remote in another one.
+# TYPE translation_coalesced_total counter
+translation_coalesced_total{scope=%q} %d
+`, scope, count)), "translation_coalesced_total"))
+}
+
+func TestTranslateLongTextInChunks(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.ChunkSize(20), translation.ChunkConcurrency(2))
+
+	original := "First sentence.\n\nSecond sentence. Third one.\n"
+
+	webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+			t.Translation = strings.ToUpper(t.Original)
+
+			return t, nil
+		},
+	)
+	repo.EXPECT().Store(gomock.Any(), entity.Translation{
+		Source:      "en",
+		Destination: "vi",
+		Original:    original,
+		Translation: "FIRST SENTENCE.\n\nSECOND SENTENCE. THIRD ONE.\n",
+	}).Times(1).Return(nil)
+
+	result, err := useCase.Translate(t.Context(), entity.Translation{Source: "en", Destination: "vi", Original: original})
+	require.NoError(t, err)
+	require.Equal(t, original, result.Original)
+	require.Equal(t, "FIRST SENTENCE.\n\nSECOND SENTENCE. THIRD ONE.\n", result.Translation)
+}
+
+func TestTranslateMarkupChunkConcurrency(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		mockCtl := gomock.NewController(t)
+		repo := NewMockTranslationRepo(mockCtl)
+		webAPI := NewMockTranslationWebAPI(mockCtl)
+		useCase := translation.New(repo, webAPI, translation.ChunkSize(20), translation.ChunkConcurrency(2))
+
+		var (
+			mu             sync.Mutex
+			inFlight, peak int
+		)
+
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(6).DoAndReturn(
+			func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+				mu.Lock()
+				inFlight++
+				peak = max(peak, inFlight)
+				mu.Unlock()
+
+				// Fake time only advances once every started call is blocked.
+				time.Sleep(time.Second)
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+
+				t.Translation = strings.ToUpper(t.Original)
+
+				return t, nil
+			},
+		)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		// Three paragraphs of two chunks each.
+		original := "First sentence. Second sentence.\n\nThird sentence. Fourth sentence.\n\nFifth sentence. Sixth sentence.\n"
+
+		result, err := useCase.Translate(t.Context(), entity.Translation{
+			Source:      "en",
+			Destination: "vi",
+			Original:    original,
+			Format:      entity.FormatMarkdown,
+		})
+		require.NoError(t, err)
+		require.Equal(t, strings.ToUpper(original), result.Translation)
+		require.Equal(t, 2, peak)
+	})
+}
+
+func TestTranslateChunkError(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.ChunkSize(20))
+
+	webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).AnyTimes().Return(entity.Translation{}, errInternalServErr)
+
+	_, err := useCase.Translate(t.Context(), entity.Translation{Original: "First sentence. Second sentence."})
+	require.Error(t, err)
+	require.Equal(t, entity.ErrExternalService.Code, entity.GetAppError(err).Code)
+}
+
+func TestTranslateMaxInputLength(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	useCase := translation.New(NewMockTranslationRepo(mockCtl), NewMockTranslationWebAPI(mockCtl), translation.MaxInputLength(5))
+
+	_, err := useCase.Translate(t.Context(), entity.Translation{Original: "текст!"})
+	require.Error(t, err)
+
+	appErr := entity.GetAppError(err)
+	require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+	require.Equal(t, "original", appErr.Details["field"])
+	require.Equal(t, "5", appErr.Details["max_length"])
+}
+
+func TestTranslateMarkup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		format      string
+		original    string
+		translation string
+	}{
+		{
+			name:        "html nested inline tags",
+			format:      entity.FormatHTML,
+			original:    `<p class="intro">Hello <b>big <i>world</i></b>!</p>`,
+			translation: `<p class="intro">HELLO <b>BIG <i>WORLD</i></b>!</p>`,
+		},
+		{
+			name:        "html entities",
+			format:      entity.FormatHTML,
+			original:    "<p>Fish &amp; chips &lt;3&nbsp;you</p>",
+			translation: "<p>FISH &amp; CHIPS &lt;3&nbsp;YOU</p>",
+		},
+		{
+			name:        "markdown fenced code",
+			format:      entity.FormatMarkdown,
+			original:    "# Title\n\nSee [docs](https://example.com/docs) and `go test`.\n\n```go\nfmt.Println(\"hi\")\n```\n",
+			translation: "# TITLE\n\nSEE [DOCS](https://example.com/docs) AND `go test`.\n\n```go\nfmt.Println(\"hi\")\n```\n",
+		},
+		{
+			name:        "markdown underscore emphasis",
+			format:      entity.FormatMarkdown,
+			original:    "Some _emphasis_ and __strong__ text in my_var.",
+			translation: "SOME _EMPHASIS_ AND __STRONG__ TEXT IN MY_VAR.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			repo := NewMockTranslationRepo(mockCtl)
+			webAPI := NewMockTranslationWebAPI(mockCtl)
+			useCase := translation.New(repo, webAPI)
+
+			webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+					t.Translation = strings.ToUpper(t.Original)
+
+					return t, nil
+				},
+			)
+			repo.EXPECT().Store(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+			result, err := useCase.Translate(t.Context(), entity.Translation{
+				Source:      "en",
+				Destination: "vi",
+				Original:    tc.original,
+				Format:      tc.format,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.translation, result.Translation)
+		})
+	}
+}
+
+func TestTranslateUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	useCase := translation.New(NewMockTranslationRepo(mockCtl), NewMockTranslationWebAPI(mockCtl))
+
+	_, err := useCase.Translate(t.Context(), entity.Translation{Original: "text", Format: "rtf"})
+	require.Error(t, err)
+
+	appErr := entity.GetAppError(err)
+	require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+	require.Equal(t, "format", appErr.Details["field"])
+}
+
+func TestTranslateLanguages(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.Languages([]entity.Language{
+		{Code: entity.LanguageAuto, Name: "Detect language", Source: true},
+		{Code: "en", Name: "English", Source: true, Destination: true},
+		{Code: "pt-BR", Name: "Portuguese (Brazil)", Source: true, Destination: true},
+	}))
+
+	languages, err := useCase.Languages(t.Context())
+	require.NoError(t, err)
+	require.Len(t, languages.Languages, 3)
+
+	t.Run("supported", func(t *testing.T) {
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(entity.Translation{Translation: "text"}, nil).Times(2)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := useCase.Translate(t.Context(), entity.Translation{Source: "auto", Destination: "PT-br", Original: "texto"})
+		require.NoError(t, err)
+
+		_, err = useCase.Translate(t.Context(), entity.Translation{Source: "pt-BR", Destination: "en-US", Original: "texto"})
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		field       string
+		reason      string
+	}{
+		{name: "unknown source", source: "enn", destination: "en", field: "source", reason: "unsupported language"},
+		{name: "unknown destination", source: "en", destination: "xx", field: "destination", reason: "unsupported language"},
+		{name: "language without the region", source: "pt", destination: "en", field: "source", reason: "unsupported language"},
+		{name: "auto destination", source: "en", destination: "auto", field: "destination", reason: "unsupported as destination"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := useCase.Translate(t.Context(), entity.Translation{Source: tc.source, Destination: tc.destination, Original: "text"})
+
+			appErr := entity.GetAppError(err)
+			require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+			require.Equal(t, tc.field, appErr.Details["field"])
+			require.Equal(t, tc.reason, appErr.Details["reason"])
+		})
+	}
+
+	t.Run("file", func(t *testing.T) {
+		_, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{
+			Format: entity.FileFormatJSON, Source: "en", Destination: "enn", Content: []byte(`{}`),
+		})
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "destination", appErr.Details["field"])
+	})
+}
+
+func TestTranslateDetectedLanguage(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.ChunkSize(20))
+
+	// The chunks of a mostly English text, one of them detected as French.
+	detections := map[string]entity.LanguageDetection{
+		"First sentence here.": {Language: "en", Confidence: 1},
+		"Bonjour le monde.":    {Language: "fr", Confidence: 0.5},
+		"Third sentence here.": {Language: "en", Confidence: 0.5},
+		"And the last one.":    {Language: "en", Confidence: 1},
+	}
+
+	webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+		func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+			t.Translation = t.Original
+			t.DetectedLanguage, t.DetectionConfidence = detections[t.Original].Language, detections[t.Original].Confidence
+
+			return t, nil
+		},
+	)
+
+	var stored entity.Translation
+
+	repo.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, t entity.Translation) error {
+		stored = t
+
+		return nil
+	})
+
+	original := "First sentence here. Bonjour le monde. Third sentence here. And the last one."
+
+	result, err := useCase.Translate(t.Context(), entity.Translation{Source: "auto", Destination: "vi", Original: original})
+	require.NoError(t, err)
+	require.Equal(t, "auto", result.Source)
+	require.Equal(t, "en", result.DetectedLanguage)
+	// (20×1 + 20×0.5 + 17×1) / 74 characters of the chunks.
+	require.InDelta(t, 47.0/74, result.DetectionConfidence, 1e-9)
+	require.Equal(t, result, stored)
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	detector := NewMockLanguageDetector(mockCtl)
+	useCase := translation.New(NewMockTranslationRepo(mockCtl), webAPI, translation.DetectionFallback(detector))
+
+	t.Run("provider", func(t *testing.T) {
+		webAPI.EXPECT().DetectLanguage(gomock.Any(), "Hallo ⟦0⟧").Return(entity.LanguageDetection{Language: "de", Confidence: 0.9}, nil)
+
+		detection, err := useCase.DetectLanguage(t.Context(), "Hallo {name}")
+		require.NoError(t, err)
+		require.Equal(t, entity.LanguageDetection{Language: "de", Confidence: 0.9}, detection)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		webAPI.EXPECT().DetectLanguage(gomock.Any(), "Hallo Welt").Return(entity.LanguageDetection{}, errInternalServErr)
+		detector.EXPECT().DetectLanguage(gomock.Any(), "Hallo Welt").Return(entity.LanguageDetection{Language: "de", Confidence: 0.6}, nil)
+
+		detection, err := useCase.DetectLanguage(t.Context(), "Hallo Welt")
+		require.NoError(t, err)
+		require.Equal(t, entity.LanguageDetection{Language: "de", Confidence: 0.6}, detection)
+	})
+
+	t.Run("fallback fails", func(t *testing.T) {
+		webAPI.EXPECT().DetectLanguage(gomock.Any(), "Hallo Welt").Return(entity.LanguageDetection{}, errInternalServErr)
+		detector.EXPECT().DetectLanguage(gomock.Any(), "Hallo Welt").Return(entity.LanguageDetection{}, errInternalServErr)
+
+		_, err := useCase.DetectLanguage(t.Context(), "Hallo Welt")
+		require.Equal(t, entity.ErrExternalService.Code, entity.GetAppError(err).Code)
+	})
+
+	t.Run("no letters", func(t *testing.T) {
+		_, err := useCase.DetectLanguage(t.Context(), "42 {name}")
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "text", appErr.Details["field"])
+	})
+}
+
+func TestTranslateGlossary(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	glossaries := NewMockGlossaryRepo(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.Glossaries(glossaries))
+
+	entries := []entity.GlossaryEntry{
+		{ID: 1, GlossaryID: 7, Term: "Acme Cloud", DoNotTranslate: true},
+		{ID: 2, GlossaryID: 7, Term: "sign in", Translation: "đăng nhập"},
+		{ID: 3, GlossaryID: 7, Term: "invoice", Translation: "hóa đơn"},
+	}
+
+	t.Run("terms enforced", func(t *testing.T) {
+		glossaries.EXPECT().GetEntries(gomock.Any(), "en", "vi").Return(entries, nil)
+		webAPI.EXPECT().Translate(gomock.Any(), entity.Translation{
+			Source:      "en",
+			Destination: "vi",
+			Original:    "⟦1⟧ ⟦2⟧ for ⟦0⟧",
+		}).Return(entity.Translation{Translation: "⟦2⟧ ⟦1⟧ cho ⟦0⟧"}, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := useCase.Translate(t.Context(), entity.Translation{
+			Source:      "en",
+			Destination: "vi",
+			Original:    "Acme Cloud Sign in for {name}",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "đăng nhập Acme Cloud cho {name}", result.Translation)
+		require.Equal(t, []entity.GlossaryEntry{entries[0], entries[1]}, result.GlossaryEntries)
+	})
+
+	t.Run("regional tags use the glossaries of their language", func(t *testing.T) {
+		glossaries.EXPECT().GetEntries(gomock.Any(), "en-US", "vi-VN").Return(nil, nil)
+		glossaries.EXPECT().GetEntries(gomock.Any(), "en", "vi").Return(entries, nil)
+		webAPI.EXPECT().Translate(gomock.Any(), entity.Translation{
+			Source:      "en-US",
+			Destination: "vi-VN",
+			Original:    "Pay the ⟦0⟧",
+		}).Return(entity.Translation{Translation: "Thanh toán ⟦0⟧"}, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := useCase.Translate(t.Context(), entity.Translation{Source: "en-US", Destination: "vi-VN", Original: "Pay the invoice"})
+		require.NoError(t, err)
+		require.Equal(t, "Thanh toán hóa đơn", result.Translation)
+		require.Equal(t, []entity.GlossaryEntry{entries[2]}, result.GlossaryEntries)
+	})
+
+	t.Run("auto source uses the detected language", func(t *testing.T) {
+		glossaries.EXPECT().GetSources(gomock.Any(), "vi").Return([]string{"de", "en-US"}, nil)
+		webAPI.EXPECT().DetectLanguage(gomock.Any(), "Pay the invoice").Return(entity.LanguageDetection{Language: "en", Confidence: 0.9}, nil)
+		glossaries.EXPECT().GetEntries(gomock.Any(), "en-US", "vi").Return(entries, nil)
+		webAPI.EXPECT().Translate(gomock.Any(), entity.Translation{
+			Source:      "auto",
+			Destination: "vi",
+			Original:    "Pay the ⟦0⟧",
+		}).Return(entity.Translation{Translation: "Thanh toán ⟦0⟧"}, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := useCase.Translate(t.Context(), entity.Translation{Source: "auto", Destination: "vi", Original: "Pay the invoice"})
+		require.NoError(t, err)
+		require.Equal(t, "Thanh toán hóa đơn", result.Translation)
+		require.Equal(t, []entity.GlossaryEntry{entries[2]}, result.GlossaryEntries)
+	})
+
+	t.Run("auto source without glossary into the destination", func(t *testing.T) {
+		glossaries.EXPECT().GetSources(gomock.Any(), "fr").Return(nil, nil)
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(entity.Translation{Translation: "Acme Cloud"}, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := useCase.Translate(t.Context(), entity.Translation{Source: "auto", Destination: "fr", Original: "Acme Cloud"})
+		require.NoError(t, err)
+		require.Empty(t, result.GlossaryEntries)
+	})
+
+	t.Run("auto source detection error", func(t *testing.T) {
+		glossaries.EXPECT().GetSources(gomock.Any(), "vi").Return([]string{"en"}, nil)
+		webAPI.EXPECT().DetectLanguage(gomock.Any(), gomock.Any()).Return(entity.LanguageDetection{}, errInternalServErr)
+
+		_, err := useCase.Translate(t.Context(), entity.Translation{Source: "auto", Destination: "vi", Original: "invoice"})
+		require.Equal(t, entity.ErrExternalService.Code, entity.GetAppError(err).Code)
+	})
+
+	t.Run("glossary error", func(t *testing.T) {
+		glossaries.EXPECT().GetEntries(gomock.Any(), "en", "vi").Return(nil, errInternalServErr)
+
+		_, err := useCase.Translate(t.Context(), entity.Translation{Source: "en", Destination: "vi", Original: "text"})
+		require.Equal(t, entity.ErrInternal.Code, entity.GetAppError(err).Code)
+	})
+}
+
+func TestTranslatePlaceholders(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI)
+
+	original := entity.Translation{Source: "en", Destination: "vi", Original: "Hello {name}, you have %d messages"}
+
+	t.Run("restored after translation", func(t *testing.T) {
+		webAPI.EXPECT().Translate(gomock.Any(), entity.Translation{
+			Source:      "en",
+			Destination: "vi",
+			Original:    "Hello ⟦0⟧, you have ⟦1⟧ messages",
+		}).Return(entity.Translation{Translation: "Xin chào ⟦0⟧, bạn có ⟦1⟧ tin nhắn"}, nil)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := useCase.Translate(t.Context(), original)
+		require.NoError(t, err)
+		require.Equal(t, original.Original, result.Original)
+		require.Equal(t, "Xin chào {name}, bạn có %d tin nhắn", result.Translation)
+	})
+
+	t.Run("lost placeholder", func(t *testing.T) {
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(entity.Translation{Translation: "Xin chào ⟦0⟧, bạn có tin nhắn"}, nil)
+
+		_, err := useCase.Translate(t.Context(), original)
+		require.Error(t, err)
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrPlaceholderLost.Code, appErr.Code)
+		require.Equal(t, "%d", appErr.Details["placeholder"])
+	})
+}
+
+func TestTranslateFile(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := NewMockTranslationRepo(mockCtl)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(repo, webAPI, translation.FileConcurrency(2))
+
+	webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+			t.Translation = strings.ToUpper(t.Original)
+
+			return t, nil
+		},
+	)
+
+	file := entity.TranslationFile{
+		Name:        "en.json",
+		Format:      entity.FileFormatJSON,
+		Source:      "en",
+		Destination: "vi",
+		Content:     []byte(`{"a": "Hello {name}", "b": {"c": ["One", "Two"]}}`),
+	}
+
+	result, err := useCase.TranslateFile(t.Context(), file)
+	require.NoError(t, err)
+	require.Equal(t, "en.json", result.Name)
+	require.JSONEq(t, `{"a": "HELLO {name}", "b": {"c": ["ONE", "TWO"]}}`, string(result.Content))
+}
+
+func TestTranslateFileSubtitles(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\n- Who?\n- You.\n")
+
+	t.Run("cues in one call", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtl := gomock.NewController(t)
+		webAPI := NewMockTranslationWebAPI(mockCtl)
+		useCase := translation.New(NewMockTranslationRepo(mockCtl), webAPI)
+
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, request entity.Translation) (entity.Translation, error) {
+				require.Equal(t, "Hello\n- Who?\n- You.", request.Original)
+
+				request.Translation = strings.ToUpper(request.Original)
+
+				return request, nil
+			},
+		)
+
+		result, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{Format: entity.FileFormatSRT, Content: content})
+		require.NoError(t, err)
+		require.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nHELLO\n\n"+
+			"2\n00:00:03,000 --> 00:00:04,000\n- WHO?\n- YOU.\n", string(result.Content))
+	})
+
+	t.Run("lines not kept", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtl := gomock.NewController(t)
+		webAPI := NewMockTranslationWebAPI(mockCtl)
+		useCase := translation.New(NewMockTranslationRepo(mockCtl), webAPI)
+
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+			func(_ context.Context, t entity.Translation) (entity.Translation, error) {
+				t.Translation = strings.ReplaceAll(strings.ToUpper(t.Original), "\n", " ")
+
+				return t, nil
+			},
+		)
+
+		result, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{Format: entity.FileFormatSRT, Content: content})
+		require.NoError(t, err)
+		require.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nHELLO\n\n"+
+			"2\n00:00:03,000 --> 00:00:04,000\n- WHO?\n- YOU.\n", string(result.Content))
+	})
+}
+
+func TestTranslateFileErrors(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	webAPI := NewMockTranslationWebAPI(mockCtl)
+	useCase := translation.New(NewMockTranslationRepo(mockCtl), webAPI)
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{Format: "csv", Content: []byte("a,b")})
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "format", appErr.Details["field"])
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		_, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{Format: entity.FileFormatJSON, Content: []byte(`{"a":`)})
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "file", appErr.Details["field"])
+		require.NotEmpty(t, appErr.Details["reason"])
+	})
+
+	t.Run("malformed subtitles", func(t *testing.T) {
+		_, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{
+			Format:  entity.FileFormatSRT,
+			Content: []byte("1\n00:00:01,000 --> 00:00:02,000\nText\n\n2\n00:00:03,000\nText\n"),
+		})
+
+		appErr := entity.GetAppError(err)
+		require.Equal(t, entity.ErrValidation.Code, appErr.Code)
+		require.Equal(t, "6", appErr.Details["line"])
+		require.Equal(t, `srt: line 6: invalid cue timing "00:00:03,000"`, appErr.Details["reason"])
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		webAPI.EXPECT().Translate(gomock.Any(), gomock.Any()).Return(entity.Translation{}, errInternalServErr)
+
+		_, err := useCase.TranslateFile(t.Context(), entity.TranslationFile{Format: entity.FileFormatJSON, Content: []byte(`{"a": "text"}`)})
+		require.Equal(t, entity.ErrExternalService.Code, entity.GetAppError(err).Code)
+	})
+}
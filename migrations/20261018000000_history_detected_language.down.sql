@@ -0,0 +1,3 @@
+-- Revert the detected language columns.
+ALTER TABLE history DROP COLUMN IF EXISTS detection_confidence;
+ALTER TABLE history DROP COLUMN IF EXISTS detected_language;
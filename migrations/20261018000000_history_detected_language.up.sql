@@ -0,0 +1,3 @@
+-- Record the language detected by the provider when the source is auto.
+ALTER TABLE history ADD COLUMN detected_language VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE history ADD COLUMN detection_confidence DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Revert glossaries.
+DROP TABLE IF EXISTS glossary_entries;
+DROP TABLE IF EXISTS glossaries;
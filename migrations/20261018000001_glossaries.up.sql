@@ -0,0 +1,20 @@
+-- Glossaries per language pair and their terms.
+CREATE TABLE IF NOT EXISTS glossaries(
+    id serial PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    source VARCHAR(35) NOT NULL,
+    destination VARCHAR(35) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_glossaries_languages ON glossaries(LOWER(source), LOWER(destination));
+
+CREATE TABLE IF NOT EXISTS glossary_entries(
+    id serial PRIMARY KEY,
+    glossary_id INTEGER NOT NULL REFERENCES glossaries(id) ON DELETE CASCADE,
+    term VARCHAR(255) NOT NULL,
+    translation VARCHAR(255) NOT NULL DEFAULT '',
+    do_not_translate BOOLEAN NOT NULL DEFAULT FALSE,
+    case_sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (glossary_id, term)
+);
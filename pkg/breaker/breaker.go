@@ -0,0 +1,178 @@
+// Package breaker implements a circuit breaker for calls to external services.
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	_defaultFailureThreshold = 5
+	_defaultOpenTimeout      = 30 * time.Second
+	_defaultHalfOpenProbes   = 1
+)
+
+// ErrOpen is returned by Allow while the circuit is open or all half-open probes are in flight.
+var ErrOpen = errors.New("breaker - circuit open")
+
+// State -.
+type State int
+
+// Circuit states.
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+// String -.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Breaker opens after FailureThreshold consecutive failures and rejects calls for OpenTimeout.
+// Then it lets HalfOpenProbes calls through: a success closes the circuit, a failure opens it again.
+type Breaker struct {
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenProbes   int
+	onStateChange    func(name string, from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// New -.
+func New(name string, opts ...Option) *Breaker {
+	b := &Breaker{
+		name:             name,
+		failureThreshold: _defaultFailureThreshold,
+		openTimeout:      _defaultOpenTimeout,
+		halfOpenProbes:   _defaultHalfOpenProbes,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Name -.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State -.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// RetryAfter returns how long the circuit stays open, 0 if it is not open.
+func (b *Breaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateOpen {
+		return 0
+	}
+
+	return max(b.openTimeout-time.Since(b.openedAt), 0)
+}
+
+// Allow returns ErrOpen if the call must not be made. Otherwise the outcome
+// must be reported with exactly one of Success, Failure or Release.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.openTimeout {
+		b.setState(StateHalfOpen)
+	}
+
+	switch b.state {
+	case StateOpen:
+		return ErrOpen
+	case StateHalfOpen:
+		if b.probes >= b.halfOpenProbes {
+			return ErrOpen
+		}
+
+		b.probes++
+	case StateClosed:
+	}
+
+	return nil
+}
+
+// Success reports a successful call and closes a half-open circuit.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+
+	if b.state == StateHalfOpen {
+		b.setState(StateClosed)
+	}
+}
+
+// Failure reports a failed call; it opens the circuit after FailureThreshold consecutive failures or a failed probe.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.failureThreshold) {
+		b.setState(StateOpen)
+	}
+}
+
+// Release reports a call without an outcome, e.g. canceled by the caller, freeing its half-open probe.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+func (b *Breaker) setState(state State) {
+	from := b.state
+
+	b.state = state
+	b.probes = 0
+
+	switch state {
+	case StateOpen:
+		b.openedAt = time.Now()
+	case StateClosed:
+		b.failures = 0
+	case StateHalfOpen:
+	}
+
+	if b.onStateChange != nil && from != state {
+		b.onStateChange(b.name, from, state)
+	}
+}
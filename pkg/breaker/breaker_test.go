@@ -0,0 +1,79 @@
+package breaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/breaker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+
+	b := breaker.New("test", breaker.FailureThreshold(3), breaker.OpenTimeout(time.Hour))
+
+	for range 2 {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+
+	// A success resets the consecutive failures.
+	require.NoError(t, b.Allow())
+	b.Success()
+
+	for range 3 {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+
+	require.Equal(t, breaker.StateOpen, b.State())
+	require.ErrorIs(t, b.Allow(), breaker.ErrOpen)
+	require.InDelta(t, time.Hour.Seconds(), b.RetryAfter().Seconds(), 1)
+}
+
+func TestBreaker_HalfOpenProbing(t *testing.T) {
+	t.Parallel()
+
+	var transitions []string
+
+	b := breaker.New("test",
+		breaker.FailureThreshold(1),
+		breaker.OpenTimeout(20*time.Millisecond),
+		breaker.OnStateChange(func(name string, from, to breaker.State) {
+			require.Equal(t, "test", name)
+
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+
+	require.NoError(t, b.Allow())
+	b.Failure()
+	require.ErrorIs(t, b.Allow(), breaker.ErrOpen)
+
+	time.Sleep(30 * time.Millisecond)
+
+	// Only one probe is let through.
+	require.NoError(t, b.Allow())
+	require.Equal(t, breaker.StateHalfOpen, b.State())
+	require.ErrorIs(t, b.Allow(), breaker.ErrOpen)
+
+	// A failed probe opens the circuit again.
+	b.Failure()
+	require.Equal(t, breaker.StateOpen, b.State())
+
+	time.Sleep(30 * time.Millisecond)
+
+	// A released probe frees its slot.
+	require.NoError(t, b.Allow())
+	b.Release()
+	require.NoError(t, b.Allow())
+
+	b.Success()
+	require.Equal(t, breaker.StateClosed, b.State())
+	require.Zero(t, b.RetryAfter())
+
+	require.Equal(t, []string{
+		"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+	}, transitions)
+}
@@ -0,0 +1,34 @@
+package breaker
+
+import "time"
+
+// Option -.
+type Option func(*Breaker)
+
+// FailureThreshold sets how many consecutive failures open the circuit.
+func FailureThreshold(failures int) Option {
+	return func(b *Breaker) {
+		b.failureThreshold = failures
+	}
+}
+
+// OpenTimeout sets how long the circuit stays open before half-open probing.
+func OpenTimeout(timeout time.Duration) Option {
+	return func(b *Breaker) {
+		b.openTimeout = timeout
+	}
+}
+
+// HalfOpenProbes sets how many concurrent calls are let through in the half-open state.
+func HalfOpenProbes(probes int) Option {
+	return func(b *Breaker) {
+		b.halfOpenProbes = probes
+	}
+}
+
+// OnStateChange is called with the breaker locked on every state change, so it must not call the breaker.
+func OnStateChange(fn func(name string, from, to State)) Option {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
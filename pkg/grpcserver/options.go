@@ -1,7 +1,10 @@
 package grpcserver
 
 import (
+	"crypto/tls"
 	"net"
+
+	pbgrpc "google.golang.org/grpc"
 )
 
 // Option -.
@@ -13,3 +16,24 @@ func Port(port string) Option {
 		s.address = net.JoinHostPort("", port)
 	}
 }
+
+// TLS serves gRPC over TLS with cfg, e.g. from tlsconfig.Server.
+func TLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.tlsConfig = cfg
+	}
+}
+
+// UnaryInterceptors are chained in the given order, after the one storing the client certificate subject.
+func UnaryInterceptors(interceptors ...pbgrpc.UnaryServerInterceptor) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, interceptors...)
+	}
+}
+
+// StreamInterceptors are chained in the given order, after the one storing the client certificate subject.
+func StreamInterceptors(interceptors ...pbgrpc.StreamServerInterceptor) Option {
+	return func(s *Server) {
+		s.streamInterceptors = append(s.streamInterceptors, interceptors...)
+	}
+}
@@ -1,14 +1,18 @@
-// Package grpcserver implements HTTP server.
+// Package grpcserver implements gRPC server.
 package grpcserver
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net"
 
 	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
 	"golang.org/x/sync/errgroup"
 	pbgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 const (
@@ -24,6 +28,10 @@ type Server struct {
 	notify  chan error
 	address string
 
+	tlsConfig          *tls.Config
+	unaryInterceptors  []pbgrpc.UnaryServerInterceptor
+	streamInterceptors []pbgrpc.StreamServerInterceptor
+
 	logger logger.Interface
 }
 
@@ -35,7 +43,6 @@ func New(l logger.Interface, opts ...Option) *Server {
 	s := &Server{
 		ctx:     ctx,
 		eg:      group,
-		App:     pbgrpc.NewServer(),
 		notify:  make(chan error, 1),
 		address: _defaultAddr,
 		logger:  l,
@@ -46,6 +53,17 @@ func New(l logger.Interface, opts ...Option) *Server {
 		opt(s)
 	}
 
+	serverOpts := []pbgrpc.ServerOption{
+		pbgrpc.ChainUnaryInterceptor(append([]pbgrpc.UnaryServerInterceptor{unaryClientSubject}, s.unaryInterceptors...)...),
+		pbgrpc.ChainStreamInterceptor(append([]pbgrpc.StreamServerInterceptor{streamClientSubject}, s.streamInterceptors...)...),
+	}
+
+	if s.tlsConfig != nil {
+		serverOpts = append(serverOpts, pbgrpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+
+	s.App = pbgrpc.NewServer(serverOpts...)
+
 	return s
 }
 
@@ -101,3 +119,51 @@ func (s *Server) Shutdown() error {
 
 	return errors.Join(shutdownErrors...)
 }
+
+// withClientSubject stores the verified client certificate subject in ctx, see tlsconfig.ClientSubject.
+func withClientSubject(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx
+	}
+
+	if subject := tlsconfig.VerifiedSubject(&info.State); subject != "" {
+		return tlsconfig.WithClientSubject(ctx, subject)
+	}
+
+	return ctx
+}
+
+func unaryClientSubject(
+	ctx context.Context,
+	req interface{},
+	_ *pbgrpc.UnaryServerInfo,
+	handler pbgrpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(withClientSubject(ctx), req)
+}
+
+func streamClientSubject(
+	srv interface{},
+	stream pbgrpc.ServerStream,
+	_ *pbgrpc.StreamServerInfo,
+	handler pbgrpc.StreamHandler,
+) error {
+	return handler(srv, &serverStream{ServerStream: stream, ctx: withClientSubject(stream.Context())})
+}
+
+// serverStream overrides the context of a stream.
+type serverStream struct {
+	pbgrpc.ServerStream
+	ctx context.Context //nolint:containedctx // the stream context
+}
+
+// Context -.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
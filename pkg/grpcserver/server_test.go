@@ -0,0 +1,72 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/evrone/go-clean-template/pkg/grpcserver"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig/tlstest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func freePort(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port) //nolint:forcetypeassert // tcp listener
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	cert := ca.Issue(t, "server")
+
+	cfg, err := tlsconfig.Server(tlsconfig.Certificate(cert.CertFile, cert.KeyFile), tlsconfig.CAFile(ca.CertFile))
+	require.NoError(t, err)
+
+	subjects := make(chan string, 1)
+	port := freePort(t)
+
+	s := grpcserver.New(logger.New("error"),
+		grpcserver.Port(port),
+		grpcserver.TLS(cfg),
+		grpcserver.UnaryInterceptors(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			subjects <- tlsconfig.ClientSubject(ctx)
+
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(s.App, health.NewServer())
+	s.Start()
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	conn, err := grpc.NewClient("localhost:"+port, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      ca.Pool(),
+		Certificates: []tls.Certificate{ca.Issue(t, "client").TLS},
+	})))
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	require.Equal(t, "CN=client", <-subjects)
+}
@@ -3,10 +3,13 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"net"
 	"time"
 
 	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"golang.org/x/sync/errgroup"
@@ -32,6 +35,7 @@ type Server struct {
 	readTimeout     time.Duration
 	writeTimeout    time.Duration
 	shutdownTimeout time.Duration
+	tlsConfig       *tls.Config
 
 	logger logger.Interface
 }
@@ -66,6 +70,10 @@ func New(l logger.Interface, opts ...Option) *Server {
 		JSONEncoder:  json.Marshal,
 	})
 
+	if s.tlsConfig != nil {
+		app.Use(clientSubject)
+	}
+
 	s.App = app
 
 	return s
@@ -74,7 +82,7 @@ func New(l logger.Interface, opts ...Option) *Server {
 // Start -.
 func (s *Server) Start() {
 	s.eg.Go(func() error {
-		err := s.App.Listen(s.address)
+		err := s.listen()
 		if err != nil {
 			s.notify <- err
 
@@ -89,6 +97,30 @@ func (s *Server) Start() {
 	s.logger.Info("restapi server - Server - Started")
 }
 
+func (s *Server) listen() error {
+	if s.tlsConfig == nil {
+		return s.App.Listen(s.address)
+	}
+
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(s.ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+
+	return s.App.Listener(tls.NewListener(ln, s.tlsConfig))
+}
+
+// clientSubject stores the verified client certificate subject in the user context, see tlsconfig.ClientSubject.
+func clientSubject(ctx *fiber.Ctx) error {
+	if subject := tlsconfig.VerifiedSubject(ctx.Context().TLSConnectionState()); subject != "" {
+		ctx.SetUserContext(tlsconfig.WithClientSubject(ctx.UserContext(), subject))
+	}
+
+	return ctx.Next()
+}
+
 // Notify -.
 func (s *Server) Notify() <-chan error {
 	return s.notify
@@ -0,0 +1,77 @@
+package httpserver_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/httpserver"
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig/tlstest"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func freePort(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port) //nolint:forcetypeassert // tcp listener
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	cert := ca.Issue(t, "server")
+
+	cfg, err := tlsconfig.Server(tlsconfig.Certificate(cert.CertFile, cert.KeyFile), tlsconfig.CAFile(ca.CertFile))
+	require.NoError(t, err)
+
+	port := freePort(t)
+
+	s := httpserver.New(logger.New("error"), httpserver.Port(port), httpserver.TLS(cfg))
+	s.App.Get("/subject", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(tlsconfig.ClientSubject(ctx.UserContext()))
+	})
+	s.Start()
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      ca.Pool(),
+		Certificates: []tls.Certificate{ca.Issue(t, "client").TLS},
+	}}}
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		resp, err = client.Get("https://localhost:" + port + "/subject") //nolint:noctx // test
+
+		return err == nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "CN=client", string(body))
+
+	// Without a client certificate the handshake fails.
+	noCert := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.Pool()}}}
+
+	_, err = noCert.Get("https://localhost:" + port + "/subject") //nolint:noctx,bodyclose // test
+	require.Error(t, err)
+}
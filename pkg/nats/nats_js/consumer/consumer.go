@@ -0,0 +1,438 @@
+// Package consumer implements a durable NATS JetStream pull consumer with retries and a dead-letter subject.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	natsjs "github.com/evrone/go-clean-template/pkg/nats/nats_js"
+	"github.com/goccy/go-json"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	_defaultWaitTime       = 5 * time.Second
+	_defaultAttempts       = 10
+	_defaultDurable        = "worker"
+	_defaultMaxDeliver     = 5
+	_defaultAckWait        = 30 * time.Second
+	_defaultBackoff        = time.Second
+	_defaultMaxBackoff     = time.Minute
+	_defaultHandlerTimeout = 10 * time.Second
+
+	_defaultResultMaxAge     = 24 * time.Hour
+	_defaultDeadLetterMaxAge = 7 * 24 * time.Hour
+	_defaultMaxMsgs          = 100_000
+	_defaultMaxBytes         = 1 << 30
+)
+
+// Retention limits a stream, the oldest messages are discarded once a limit is reached. Zero means no limit.
+type Retention struct {
+	MaxAge   time.Duration
+	MaxMsgs  int64
+	MaxBytes int64
+}
+
+// Handler processes a message and returns the result to publish to the result subject.
+// Errors wrapping natsjs.ErrTerminal are not retried.
+type Handler func(context.Context, jetstream.Msg) (interface{}, error)
+
+// Consumer -.
+type Consumer struct {
+	ctx context.Context
+	eg  *errgroup.Group
+
+	// serveCtx is the context of handlers, canceled once buffered messages are processed on shutdown.
+	serveCtx    context.Context
+	cancelServe context.CancelFunc
+
+	connection *nats.Conn
+	js         jetstream.JetStream
+	consume    jetstream.ConsumeContext
+	router     map[string]Handler
+	stop       chan struct{}
+	notify     chan error
+
+	stream            string
+	subject           string
+	durable           string
+	resultStream      string
+	resultSubject     string
+	deadLetterStream  string
+	deadLetterSubject string
+
+	resultRetention     Retention
+	deadLetterRetention Retention
+
+	maxDeliver     int
+	ackWait        time.Duration
+	backoff        time.Duration
+	maxBackoff     time.Duration
+	handlerTimeout time.Duration
+	connOpts       []nats.Option
+
+	logger logger.Interface
+}
+
+// New creates the streams and the durable consumer. Messages are routed by the subject
+// suffix after subjectPrefix, e.g. <subjectPrefix>.v1.translate is served by router["v1.translate"].
+func New(
+	url,
+	stream,
+	subjectPrefix string,
+	router map[string]Handler,
+	l logger.Interface,
+	opts ...Option,
+) (*Consumer, error) {
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(1) // Run only one goroutine
+
+	serveCtx, cancelServe := context.WithCancel(context.Background())
+
+	c := &Consumer{
+		ctx:            ctx,
+		eg:             group,
+		serveCtx:       serveCtx,
+		cancelServe:    cancelServe,
+		router:         router,
+		stop:           make(chan struct{}),
+		notify:         make(chan error, 1),
+		stream:         stream,
+		subject:        subjectPrefix,
+		durable:        _defaultDurable,
+		maxDeliver:     _defaultMaxDeliver,
+		ackWait:        _defaultAckWait,
+		backoff:        _defaultBackoff,
+		maxBackoff:     _defaultMaxBackoff,
+		handlerTimeout: _defaultHandlerTimeout,
+		resultRetention: Retention{
+			MaxAge:   _defaultResultMaxAge,
+			MaxMsgs:  _defaultMaxMsgs,
+			MaxBytes: _defaultMaxBytes,
+		},
+		deadLetterRetention: Retention{
+			MaxAge:   _defaultDeadLetterMaxAge,
+			MaxMsgs:  _defaultMaxMsgs,
+			MaxBytes: _defaultMaxBytes,
+		},
+		logger: l,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	connection, err := nats.Connect(
+		url,
+		append([]nats.Option{
+			nats.ReconnectWait(_defaultWaitTime),
+			nats.MaxReconnects(_defaultAttempts),
+			nats.Timeout(_defaultWaitTime),
+		}, c.connOpts...)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nats_js consumer - New - nats.Connect: %w", err)
+	}
+
+	c.connection = connection
+
+	c.js, err = jetstream.New(connection)
+	if err != nil {
+		connection.Close()
+
+		return nil, fmt.Errorf("nats_js consumer - New - jetstream.New: %w", err)
+	}
+
+	err = c.declare()
+	if err != nil {
+		connection.Close()
+
+		return nil, fmt.Errorf("nats_js consumer - New - c.declare: %w", err)
+	}
+
+	return c, nil
+}
+
+// Start -.
+func (c *Consumer) Start() {
+	c.eg.Go(func() error {
+		err := c.start()
+		if err != nil {
+			c.notify <- err
+
+			close(c.notify)
+
+			return err
+		}
+
+		// Wait for stop signal
+		<-c.stop
+
+		return nil
+	})
+
+	c.logger.Info("nats_js consumer - Consumer - Started")
+}
+
+// Notify -.
+func (c *Consumer) Notify() <-chan error {
+	return c.notify
+}
+
+// Shutdown stops fetching, waits for buffered messages to be processed and closes the connection.
+func (c *Consumer) Shutdown() error {
+	var shutdownErrors []error
+
+	close(c.stop)
+
+	// Wait for all goroutines to finish and get any error
+	err := c.eg.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.logger.Error(err, "nats_js consumer - Consumer - Shutdown - c.eg.Wait")
+
+		shutdownErrors = append(shutdownErrors, err)
+	}
+
+	if c.consume != nil {
+		c.consume.Drain()
+		<-c.consume.Closed()
+	}
+
+	err = c.connection.Drain()
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - Shutdown - c.connection.Drain")
+
+		shutdownErrors = append(shutdownErrors, err)
+	}
+
+	c.cancelServe()
+
+	c.logger.Info("nats_js consumer - Consumer - Shutdown")
+
+	return errors.Join(shutdownErrors...)
+}
+
+func (c *Consumer) declare() error {
+	ctx, cancel := context.WithTimeout(context.Background(), _defaultWaitTime)
+	defer cancel()
+
+	streams := []jetstream.StreamConfig{{Name: c.stream, Subjects: []string{c.subject + ".>"}}}
+
+	if c.resultStream != "" {
+		streams = append(streams, limitedStream(c.resultStream, c.resultSubject, c.resultRetention))
+	}
+
+	if c.deadLetterStream != "" {
+		streams = append(streams, limitedStream(c.deadLetterStream, c.deadLetterSubject, c.deadLetterRetention))
+	}
+
+	for _, cfg := range streams {
+		_, err := c.js.CreateOrUpdateStream(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("c.js.CreateOrUpdateStream(%s): %w", cfg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// limitedStream returns the config of a stream holding the messages of subject within the retention limits.
+func limitedStream(name, subject string, retention Retention) jetstream.StreamConfig {
+	return jetstream.StreamConfig{
+		Name:     name,
+		Subjects: []string{subject},
+		MaxAge:   retention.MaxAge,
+		MaxMsgs:  retention.MaxMsgs,
+		MaxBytes: retention.MaxBytes,
+	}
+}
+
+func (c *Consumer) start() error {
+	ctx, cancel := context.WithTimeout(c.ctx, _defaultWaitTime)
+	defer cancel()
+
+	filterSubjects := make([]string, 0, len(c.router))
+	for route := range c.router {
+		filterSubjects = append(filterSubjects, c.subject+"."+route)
+	}
+
+	consumer, err := c.js.CreateOrUpdateConsumer(ctx, c.stream, jetstream.ConsumerConfig{
+		Durable:        c.durable,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        c.ackWait,
+		MaxDeliver:     c.maxDeliver,
+		FilterSubjects: filterSubjects,
+	})
+	if err != nil {
+		return fmt.Errorf("nats_js consumer - start - c.js.CreateOrUpdateConsumer: %w", err)
+	}
+
+	c.consume, err = consumer.Consume(c.handleMessage, jetstream.ConsumeErrHandler(
+		func(_ jetstream.ConsumeContext, err error) {
+			c.logger.Error(err, "nats_js consumer - Consumer - ConsumeErrHandler")
+		},
+	))
+	if err != nil {
+		return fmt.Errorf("nats_js consumer - start - consumer.Consume: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Consumer) handleMessage(msg jetstream.Msg) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - handleMessage - msg.Metadata")
+
+		c.term(msg, err)
+
+		return
+	}
+
+	result, err := c.serveCall(msg)
+	if err == nil {
+		err = c.publishResult(msg, meta, result)
+	}
+
+	if err == nil {
+		c.ack(msg)
+
+		return
+	}
+
+	c.logger.Error(err, "nats_js consumer - Consumer - handleMessage - delivery %d/%d", meta.NumDelivered, c.maxDeliver)
+
+	if errors.Is(err, natsjs.ErrTerminal) || int(meta.NumDelivered) >= c.maxDeliver { //nolint:gosec // deliveries are bounded by maxDeliver
+		c.deadLetter(msg, meta, err)
+
+		return
+	}
+
+	c.nak(msg, c.retryDelay(meta.NumDelivered))
+}
+
+func (c *Consumer) serveCall(msg jetstream.Msg) (interface{}, error) {
+	route := strings.TrimPrefix(msg.Subject(), c.subject+".")
+
+	handler, ok := c.router[route]
+	if !ok {
+		return nil, fmt.Errorf("%w: %w: %s", natsjs.ErrTerminal, natsjs.ErrBadHandler, route)
+	}
+
+	ctx, cancel := context.WithTimeout(c.serveCtx, c.handlerTimeout)
+	defer cancel()
+
+	return handler(ctx, msg)
+}
+
+func (c *Consumer) publishResult(msg jetstream.Msg, meta *jetstream.MsgMetadata, result interface{}) error {
+	if c.resultSubject == "" || result == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("%w: json.Marshal: %w", natsjs.ErrTerminal, err)
+	}
+
+	resultMsg := nats.NewMsg(c.resultSubject)
+	copyHeaders(resultMsg.Header, msg.Headers())
+	resultMsg.Header.Set(natsjs.HeaderOriginalSubject, msg.Subject())
+	resultMsg.Data = body
+
+	ctx, cancel := context.WithTimeout(c.serveCtx, _defaultWaitTime)
+	defer cancel()
+
+	// The message id deduplicates results when a delivery is retried after a lost ack.
+	_, err = c.js.PublishMsg(ctx, resultMsg, jetstream.WithMsgID(messageID(meta)))
+	if err != nil {
+		return fmt.Errorf("c.js.PublishMsg(%s): %w", c.resultSubject, err)
+	}
+
+	return nil
+}
+
+func (c *Consumer) deadLetter(msg jetstream.Msg, meta *jetstream.MsgMetadata, cause error) {
+	if c.deadLetterSubject == "" {
+		c.term(msg, cause)
+
+		return
+	}
+
+	dlqMsg := nats.NewMsg(c.deadLetterSubject)
+	copyHeaders(dlqMsg.Header, msg.Headers())
+	dlqMsg.Header.Set(natsjs.HeaderError, cause.Error())
+	dlqMsg.Header.Set(natsjs.HeaderDeliveries, strconv.FormatUint(meta.NumDelivered, 10))
+	dlqMsg.Header.Set(natsjs.HeaderOriginalSubject, msg.Subject())
+	dlqMsg.Header.Set(natsjs.HeaderOriginalSequence, strconv.FormatUint(meta.Sequence.Stream, 10))
+	dlqMsg.Data = msg.Data()
+
+	ctx, cancel := context.WithTimeout(c.serveCtx, _defaultWaitTime)
+	defer cancel()
+
+	_, err := c.js.PublishMsg(ctx, dlqMsg, jetstream.WithMsgID(messageID(meta)))
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - deadLetter - c.js.PublishMsg")
+
+		// Keep the message in the stream for another attempt rather than losing it.
+		c.nak(msg, c.retryDelay(meta.NumDelivered))
+
+		return
+	}
+
+	c.term(msg, cause)
+}
+
+// retryDelay returns backoff * 2^(delivered-1), capped by maxBackoff.
+func (c *Consumer) retryDelay(delivered uint64) time.Duration {
+	delay := c.backoff
+
+	for i := uint64(1); i < delivered && delay < c.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, c.maxBackoff)
+}
+
+func (c *Consumer) ack(msg jetstream.Msg) {
+	err := msg.Ack()
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - ack - msg.Ack")
+	}
+}
+
+func (c *Consumer) nak(msg jetstream.Msg, delay time.Duration) {
+	err := msg.NakWithDelay(delay)
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - nak - msg.NakWithDelay")
+	}
+}
+
+func (c *Consumer) term(msg jetstream.Msg, cause error) {
+	err := msg.TermWithReason(cause.Error())
+	if err != nil {
+		c.logger.Error(err, "nats_js consumer - Consumer - term - msg.TermWithReason")
+	}
+}
+
+func messageID(meta *jetstream.MsgMetadata) string {
+	return meta.Stream + "-" + strconv.FormatUint(meta.Sequence.Stream, 10)
+}
+
+func copyHeaders(dst, src nats.Header) {
+	for k, v := range src {
+		if strings.HasPrefix(k, "Nats-") {
+			continue
+		}
+
+		dst[k] = v
+	}
+}
@@ -0,0 +1,225 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	natsjs "github.com/evrone/go-clean-template/pkg/nats/nats_js"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_js/consumer"
+	"github.com/evrone/go-clean-template/pkg/nats/natstest"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStream        = "REQUESTS"
+	testSubject       = "requests"
+	testRoute         = "v1.echo"
+	testResultSubject = "results"
+	testDLQSubject    = "dlq"
+	testMaxDeliver    = 3
+)
+
+var errTemporary = errors.New("temporary failure")
+
+// startConsumer starts a consumer whose handler fails the first `failures` deliveries.
+func startConsumer(t *testing.T, url string, failures int64, failErr error) *atomic.Int64 {
+	t.Helper()
+
+	var calls atomic.Int64
+
+	router := map[string]consumer.Handler{
+		testRoute: func(_ context.Context, msg jetstream.Msg) (interface{}, error) {
+			if calls.Add(1) <= failures {
+				return nil, failErr
+			}
+
+			return map[string]string{"echo": string(msg.Data())}, nil
+		},
+	}
+
+	c, err := consumer.New(url, testStream, testSubject, router, logger.New("error"),
+		consumer.Durable("test-worker"),
+		consumer.MaxDeliver(testMaxDeliver),
+		consumer.Backoff(10*time.Millisecond, 50*time.Millisecond),
+		consumer.ResultStream("RESULTS", testResultSubject),
+		consumer.DeadLetterStream("DLQ", testDLQSubject),
+	)
+	require.NoError(t, err)
+
+	c.Start()
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Shutdown())
+	})
+
+	return &calls
+}
+
+func connect(t *testing.T, url string) jetstream.JetStream {
+	t.Helper()
+
+	nc, err := nats.Connect(url)
+	require.NoError(t, err)
+
+	t.Cleanup(nc.Close)
+
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+
+	return js
+}
+
+func nextMessage(t *testing.T, js jetstream.JetStream, stream string) jetstream.Msg {
+	t.Helper()
+
+	cons, err := js.OrderedConsumer(t.Context(), stream, jetstream.OrderedConsumerConfig{})
+	require.NoError(t, err)
+
+	msg, err := cons.Next(jetstream.FetchMaxWait(5 * time.Second))
+	require.NoError(t, err)
+
+	return msg
+}
+
+func publish(t *testing.T, js jetstream.JetStream, route, data string) {
+	t.Helper()
+
+	_, err := js.Publish(t.Context(), fmt.Sprintf("%s.%s", testSubject, route), []byte(data))
+	require.NoError(t, err)
+}
+
+func TestConsumer_PublishesResult(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+	calls := startConsumer(t, url, 0, nil)
+	js := connect(t, url)
+
+	publish(t, js, testRoute, "hello")
+
+	msg := nextMessage(t, js, "RESULTS")
+	require.JSONEq(t, `{"echo":"hello"}`, string(msg.Data()))
+	require.Equal(t, testSubject+"."+testRoute, msg.Headers().Get(natsjs.HeaderOriginalSubject))
+	require.EqualValues(t, 1, calls.Load())
+}
+
+func TestConsumer_RetriesThenSucceeds(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+	calls := startConsumer(t, url, testMaxDeliver-1, errTemporary)
+	js := connect(t, url)
+
+	publish(t, js, testRoute, "hello")
+
+	msg := nextMessage(t, js, "RESULTS")
+	require.JSONEq(t, `{"echo":"hello"}`, string(msg.Data()))
+	require.EqualValues(t, testMaxDeliver, calls.Load())
+}
+
+func TestConsumer_DeadLettersAfterMaxDeliver(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+	calls := startConsumer(t, url, testMaxDeliver, errTemporary)
+	js := connect(t, url)
+
+	publish(t, js, testRoute, "hello")
+
+	msg := nextMessage(t, js, "DLQ")
+	require.Equal(t, "hello", string(msg.Data()))
+	require.Equal(t, "3", msg.Headers().Get(natsjs.HeaderDeliveries))
+	require.Equal(t, errTemporary.Error(), msg.Headers().Get(natsjs.HeaderError))
+	require.EqualValues(t, testMaxDeliver, calls.Load())
+}
+
+func TestConsumer_TerminalErrorSkipsRetries(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+	calls := startConsumer(t, url, 1, fmt.Errorf("%w: bad payload", natsjs.ErrTerminal))
+	js := connect(t, url)
+
+	publish(t, js, testRoute, "hello")
+
+	msg := nextMessage(t, js, "DLQ")
+	require.Equal(t, "1", msg.Headers().Get(natsjs.HeaderDeliveries))
+	require.EqualValues(t, 1, calls.Load())
+}
+
+func TestConsumer_ShutdownKeepsHandlerContext(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+
+	var calls atomic.Int64
+
+	router := map[string]consumer.Handler{
+		testRoute: func(ctx context.Context, msg jetstream.Msg) (interface{}, error) {
+			calls.Add(1)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(300 * time.Millisecond):
+			}
+
+			return map[string]string{"echo": string(msg.Data())}, ctx.Err()
+		},
+	}
+
+	c, err := consumer.New(url, testStream, testSubject, router, logger.New("error"),
+		consumer.ResultStream("RESULTS", testResultSubject),
+	)
+	require.NoError(t, err)
+
+	c.Start()
+
+	js := connect(t, url)
+
+	publish(t, js, testRoute, "hello")
+
+	// The handler context must outlive the shutdown until the result is published.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, c.Shutdown())
+
+	msg := nextMessage(t, js, "RESULTS")
+	require.JSONEq(t, `{"echo":"hello"}`, string(msg.Data()))
+}
+
+func TestConsumer_StreamRetention(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.RunJetStream(t)
+
+	c, err := consumer.New(url, testStream, testSubject, map[string]consumer.Handler{}, logger.New("error"),
+		consumer.ResultStream("RESULTS", testResultSubject),
+		consumer.DeadLetterStream("DLQ", testDLQSubject),
+		consumer.DeadLetterRetention(consumer.Retention{MaxAge: time.Hour, MaxMsgs: 10, MaxBytes: 1 << 20}),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Shutdown())
+	})
+
+	js := connect(t, url)
+
+	results, err := js.Stream(t.Context(), "RESULTS")
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, results.CachedInfo().Config.MaxAge)
+	require.EqualValues(t, 100_000, results.CachedInfo().Config.MaxMsgs)
+	require.EqualValues(t, 1<<30, results.CachedInfo().Config.MaxBytes)
+
+	dlq, err := js.Stream(t.Context(), "DLQ")
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, dlq.CachedInfo().Config.MaxAge)
+	require.EqualValues(t, 10, dlq.CachedInfo().Config.MaxMsgs)
+	require.EqualValues(t, 1<<20, dlq.CachedInfo().Config.MaxBytes)
+}
@@ -0,0 +1,83 @@
+package consumer
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Option -.
+type Option func(*Consumer)
+
+// Durable sets the durable consumer name shared by all instances.
+func Durable(name string) Option {
+	return func(c *Consumer) {
+		c.durable = name
+	}
+}
+
+// MaxDeliver sets how many times a message is delivered before it is moved to the dead-letter subject.
+func MaxDeliver(maxDeliver int) Option {
+	return func(c *Consumer) {
+		c.maxDeliver = maxDeliver
+	}
+}
+
+// AckWait -.
+func AckWait(timeout time.Duration) Option {
+	return func(c *Consumer) {
+		c.ackWait = timeout
+	}
+}
+
+// Backoff sets the initial and maximum redelivery delay after a failed attempt, doubled on every attempt.
+func Backoff(initial, maxDelay time.Duration) Option {
+	return func(c *Consumer) {
+		c.backoff = initial
+		c.maxBackoff = maxDelay
+	}
+}
+
+// HandlerTimeout -.
+func HandlerTimeout(timeout time.Duration) Option {
+	return func(c *Consumer) {
+		c.handlerTimeout = timeout
+	}
+}
+
+// ResultStream sets the stream and subject handler results are published to.
+func ResultStream(stream, subject string) Option {
+	return func(c *Consumer) {
+		c.resultStream = stream
+		c.resultSubject = subject
+	}
+}
+
+// DeadLetterStream sets the stream and subject failed messages are published to.
+func DeadLetterStream(stream, subject string) Option {
+	return func(c *Consumer) {
+		c.deadLetterStream = stream
+		c.deadLetterSubject = subject
+	}
+}
+
+// ResultRetention limits the result stream, by default to a day, 100000 messages and 1 GiB.
+func ResultRetention(retention Retention) Option {
+	return func(c *Consumer) {
+		c.resultRetention = retention
+	}
+}
+
+// DeadLetterRetention limits the dead-letter stream, by default to a week, 100000 messages and 1 GiB.
+func DeadLetterRetention(retention Retention) Option {
+	return func(c *Consumer) {
+		c.deadLetterRetention = retention
+	}
+}
+
+// ConnOptions adds nats.Connect options, e.g. nats.Secure, nats.UserCredentials or nats.Nkey.
+func ConnOptions(opts ...nats.Option) Option {
+	return func(c *Consumer) {
+		c.connOpts = append(c.connOpts, opts...)
+	}
+}
@@ -0,0 +1,18 @@
+package natsjs
+
+import "errors"
+
+var (
+	// ErrTerminal marks a handler error that must not be retried, the message goes straight to the dead-letter subject.
+	ErrTerminal = errors.New("terminal error")
+	// ErrBadHandler -.
+	ErrBadHandler = errors.New("unregistered handler")
+)
+
+// Headers set on dead-letter messages.
+const (
+	HeaderError            = "Error"
+	HeaderDeliveries       = "Deliveries"
+	HeaderOriginalSubject  = "Original-Subject"
+	HeaderOriginalSequence = "Original-Sequence"
+)
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Option -.
+type Option func(*Server)
+
+// Name sets the micro service name shown by `nats micro ls`.
+func Name(name string) Option {
+	return func(s *Server) {
+		s.name = name
+	}
+}
+
+// Version sets the SemVer version of the micro service.
+func Version(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// Description -.
+func Description(description string) Option {
+	return func(s *Server) {
+		s.description = description
+	}
+}
+
+// Metadata adds metadata to the service and to every endpoint.
+func Metadata(metadata map[string]string) Option {
+	return func(s *Server) {
+		for k, v := range metadata {
+			s.metadata[k] = v
+		}
+	}
+}
+
+// QueueGroup overrides the micro default queue group ("q").
+func QueueGroup(queue string) Option {
+	return func(s *Server) {
+		s.queue = queue
+	}
+}
+
+// DrainTimeout -.
+func DrainTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// ConnOptions adds options to the natsrpc.Connect connection of the service.
+func ConnOptions(opts ...nats.Option) Option {
+	return func(s *Server) {
+		s.connOpts = append(s.connOpts, opts...)
+	}
+}
@@ -0,0 +1,311 @@
+// Package server implements NATS RPC server on top of the NATS micro service framework,
+// so the service, its endpoints and their stats are visible via `nats micro ls|info|stats`.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	natsrpc "github.com/evrone/go-clean-template/pkg/nats/nats_rpc"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	_defaultDrainTimeout = 10 * time.Second
+	_defaultVersion      = "0.0.0"
+
+	// _dispatchEndpoint serves clients that call the shared subject with a Handler header.
+	_dispatchEndpoint = "dispatch"
+
+	_codeBadHandler     = "404"
+	_codeInternalServer = "500"
+)
+
+// ErrDrainTimeout -.
+var ErrDrainTimeout = natsrpc.ErrDrainTimeout
+
+// Server -.
+type Server struct {
+	ctx context.Context
+	eg  *errgroup.Group
+
+	subject    string
+	connection *natsrpc.Conn
+	mu         sync.RWMutex
+	service    micro.Service
+	router     *rpc.Router
+	stop       chan struct{}
+	notify     chan error
+
+	name         string
+	version      string
+	description  string
+	metadata     map[string]string
+	queue        string
+	drainTimeout time.Duration
+	connOpts     []nats.Option
+
+	logger logger.Interface
+}
+
+// New -.
+func New(
+	url,
+	serverSubject string,
+	router *rpc.Router,
+	l logger.Interface,
+	opts ...Option,
+) (*Server, error) {
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(1) // Run only one goroutine
+
+	s := &Server{
+		ctx:          ctx,
+		eg:           group,
+		subject:      serverSubject,
+		router:       router,
+		stop:         make(chan struct{}),
+		notify:       make(chan error, 1),
+		name:         endpointName(serverSubject),
+		version:      _defaultVersion,
+		metadata:     make(map[string]string),
+		drainTimeout: _defaultDrainTimeout,
+		logger:       l,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	connection, err := natsrpc.Connect(url, s.drainTimeout, s.connOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("nats_micro server - NewServer - natsrpc.Connect: %w", err)
+	}
+
+	s.connection = connection
+
+	return s, nil
+}
+
+// Start -.
+func (s *Server) Start() {
+	s.eg.Go(func() error {
+		err := s.addService()
+		if err != nil {
+			s.notify <- err
+
+			close(s.notify)
+
+			return err
+		}
+
+		// Wait for stop signal
+		<-s.stop
+
+		return nil
+	})
+
+	s.logger.Info("nats_micro server - Server - Started")
+}
+
+// Notify -.
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Shutdown stops the micro service, drains in-flight requests and closes the connection.
+func (s *Server) Shutdown() error {
+	var shutdownErrors []error
+
+	close(s.stop)
+
+	// Wait for all goroutines to finish and get any error
+	err := s.eg.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		s.logger.Error(err, "nats_micro server - Server - Shutdown - s.eg.Wait")
+
+		shutdownErrors = append(shutdownErrors, err)
+	}
+
+	if service := s.getService(); service != nil {
+		err = service.Stop()
+		if err != nil {
+			s.logger.Error(err, "nats_micro server - Server - Shutdown - s.service.Stop")
+
+			shutdownErrors = append(shutdownErrors, err)
+		}
+	}
+
+	// Drain subscriptions and close connection
+	err = s.connection.Drain()
+	if err != nil {
+		s.logger.Error(err, "nats_micro server - Server - Shutdown - s.connection.Drain")
+
+		shutdownErrors = append(shutdownErrors, err)
+	}
+
+	s.logger.Info("nats_micro server - Server - Shutdown")
+
+	return errors.Join(shutdownErrors...)
+}
+
+// Info returns the micro service info (name, id, version, endpoints), empty until the server is started.
+func (s *Server) Info() micro.Info {
+	service := s.getService()
+	if service == nil {
+		return micro.Info{}
+	}
+
+	return service.Info()
+}
+
+// Stats returns request, error and processing time counters for every endpoint, empty until the server is started.
+func (s *Server) Stats() micro.Stats {
+	service := s.getService()
+	if service == nil {
+		return micro.Stats{}
+	}
+
+	return service.Stats()
+}
+
+func (s *Server) getService() micro.Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.service
+}
+
+func (s *Server) addService() error {
+	config := micro.Config{
+		Name:        s.name,
+		Version:     s.version,
+		Description: s.description,
+		Metadata:    s.metadata,
+		QueueGroup:  s.queue,
+		ErrorHandler: func(_ micro.Service, natsErr *micro.NATSError) {
+			s.logger.Error(natsErr, "nats_micro server - Server - ErrorHandler")
+		},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service, err := micro.AddService(s.connection.Conn, config)
+	if err != nil {
+		return fmt.Errorf("nats_micro server - addService - micro.AddService: %w", err)
+	}
+
+	s.service = service
+
+	err = service.AddEndpoint(_dispatchEndpoint, micro.HandlerFunc(s.dispatch),
+		micro.WithEndpointSubject(s.subject),
+		micro.WithEndpointMetadata(s.endpointMetadata(_dispatchEndpoint)),
+	)
+	if err != nil {
+		return fmt.Errorf("nats_micro server - addService - service.AddEndpoint(%s): %w", _dispatchEndpoint, err)
+	}
+
+	group := service.AddGroup(s.subject)
+
+	for _, handler := range s.router.Handlers() {
+		err = group.AddEndpoint(endpointName(handler), s.endpoint(handler),
+			micro.WithEndpointSubject(handler),
+			micro.WithEndpointMetadata(s.endpointMetadata(handler)),
+		)
+		if err != nil {
+			return fmt.Errorf("nats_micro server - addService - group.AddEndpoint(%s): %w", handler, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) endpointMetadata(handler string) map[string]string {
+	metadata := make(map[string]string, len(s.metadata)+2) //nolint:mnd // handler and version keys
+
+	for k, v := range s.metadata {
+		metadata[k] = v
+	}
+
+	metadata["handler"] = handler
+	metadata["version"] = s.version
+
+	return metadata
+}
+
+func (s *Server) dispatch(req micro.Request) {
+	s.serveCall(req, req.Headers().Get(natsrpc.HeaderHandler))
+}
+
+func (s *Server) endpoint(handler string) micro.HandlerFunc {
+	return func(req micro.Request) {
+		s.serveCall(req, handler)
+	}
+}
+
+func (s *Server) serveCall(req micro.Request, handler string) {
+	response, err := s.router.Serve(s.connection.Context(), &rpc.Request{
+		Handler:     handler,
+		ContentType: req.Headers().Get(natsrpc.HeaderContentType),
+		Header:      natsrpc.Headers(nats.Header(req.Headers())),
+		Body:        req.Data(),
+	})
+	if err != nil {
+		s.logger.Error(err, "nats_micro server - Server - serveCall - s.router.Serve")
+	}
+
+	header := natsrpc.Header(response.Header)
+	header.Set(natsrpc.HeaderStatus, response.Status)
+	header.Set(natsrpc.HeaderContentType, response.ContentType)
+
+	if response.Status != natsrpc.Success {
+		s.respondError(req, response, header)
+
+		return
+	}
+
+	err = req.Respond(response.Body, micro.WithHeaders(micro.Headers(header)))
+	if err != nil {
+		s.logger.Error(err, "nats_micro server - Server - serveCall - req.Respond")
+	}
+}
+
+// respondError replies with the micro error headers, used for error stats, and the Status header natsrpc clients expect.
+// Application errors keep their body, so clients can decode the rpc.Error.
+func (s *Server) respondError(req micro.Request, response *rpc.Response, header nats.Header) {
+	code := _codeInternalServer
+
+	switch response.Status {
+	case natsrpc.ErrBadHandler.Error():
+		code = _codeBadHandler
+	case rpc.StatusError:
+		code = response.Header[rpc.HeaderErrorCode]
+	}
+
+	err := req.Error(code, response.Err().Error(), response.Body, micro.WithHeaders(micro.Headers(header)))
+	if err != nil {
+		s.logger.Error(err, "nats_micro server - Server - respondError - req.Error")
+	}
+}
+
+// endpointName maps a router key such as v1.getHistory to a valid micro name (v1_getHistory).
+func endpointName(handler string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, handler)
+}
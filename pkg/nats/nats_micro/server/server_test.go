@@ -0,0 +1,164 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_micro/server"
+	natsrpc "github.com/evrone/go-clean-template/pkg/nats/nats_rpc"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_rpc/client"
+	"github.com/evrone/go-clean-template/pkg/nats/natstest"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubject = "rpc_server"
+
+var errHandler = errors.New("handler failed")
+
+func startServer(t *testing.T, url string) *server.Server {
+	t.Helper()
+
+	router := rpc.NewRouter()
+	router.Handle("v1.echo", func(_ context.Context, req *rpc.Request) (interface{}, error) {
+		return string(req.Body), nil
+	})
+	router.Handle("v1.fail", func(context.Context, *rpc.Request) (interface{}, error) {
+		return nil, errHandler
+	})
+
+	s, err := server.New(url, testSubject, router, logger.New("error"),
+		server.Name("go-clean-template"),
+		server.Version("1.2.3"),
+		server.Metadata(map[string]string{"env": "test"}),
+	)
+	require.NoError(t, err)
+
+	s.Start()
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	require.Eventually(t, func() bool { return len(s.Info().Endpoints) == len(router.Handlers())+1 }, time.Second, 10*time.Millisecond)
+
+	return s
+}
+
+func newClient(t *testing.T, url string, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	c, err := client.New(url, testSubject, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Shutdown())
+	})
+
+	return c
+}
+
+func TestServer_RegistersEndpoints(t *testing.T) {
+	t.Parallel()
+
+	s := startServer(t, natstest.Run(t))
+
+	info := s.Info()
+	require.Equal(t, "go-clean-template", info.Name)
+	require.Equal(t, "1.2.3", info.Version)
+	require.Equal(t, "test", info.Metadata["env"])
+
+	subjects := make(map[string]string, len(info.Endpoints))
+	for _, e := range info.Endpoints {
+		subjects[e.Name] = e.Subject
+
+		require.Equal(t, "1.2.3", e.Metadata["version"])
+	}
+
+	require.Equal(t, map[string]string{
+		"dispatch": "rpc_server",
+		"v1_echo":  "rpc_server.v1.echo",
+		"v1_fail":  "rpc_server.v1.fail",
+	}, subjects)
+}
+
+func TestServer_ServesCallsAndCountsStats(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+	s := startServer(t, url)
+
+	var resp string
+
+	// Handler header on the shared subject.
+	err := newClient(t, url).RemoteCall("v1.echo", "ping", &resp)
+	require.NoError(t, err)
+	require.Equal(t, `"ping"`, resp)
+
+	// Dedicated subject per handler.
+	perHandler := newClient(t, url, client.SubjectPerHandler(true))
+
+	err = perHandler.RemoteCall("v1.echo", "pong", &resp)
+	require.NoError(t, err)
+	require.Equal(t, `"pong"`, resp)
+
+	err = perHandler.RemoteCall("v1.fail", nil, &resp)
+	require.ErrorIs(t, err, natsrpc.ErrInternalServer)
+
+	err = newClient(t, url).RemoteCall("v1.unknown", nil, &resp)
+	require.ErrorIs(t, err, natsrpc.ErrBadHandler)
+
+	stats := make(map[string][2]int)
+	for _, e := range s.Stats().Endpoints {
+		stats[e.Name] = [2]int{e.NumRequests, e.NumErrors}
+	}
+
+	require.Equal(t, [2]int{2, 1}, stats["dispatch"])
+	require.Equal(t, [2]int{1, 0}, stats["v1_echo"])
+	require.Equal(t, [2]int{1, 1}, stats["v1_fail"])
+}
+
+func TestServer_ShutdownKeepsHandlerContext(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+
+	var calls atomic.Int64
+
+	router := rpc.NewRouter()
+	router.Handle("v1.echo", func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+		calls.Add(1)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(300 * time.Millisecond):
+		}
+
+		return string(req.Body), ctx.Err()
+	})
+
+	s, err := server.New(url, testSubject, router, logger.New("error"))
+	require.NoError(t, err)
+
+	s.Start()
+
+	require.Eventually(t, func() bool { return len(s.Info().Endpoints) == 2 }, time.Second, 10*time.Millisecond)
+
+	c := newClient(t, url, client.Timeout(2*time.Second))
+	result := make(chan error, 1)
+
+	go func() {
+		var resp string
+
+		result <- c.RemoteCall("v1.echo", "ping", &resp)
+	}()
+
+	// The handler context must outlive the shutdown until the request is answered.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown())
+	require.NoError(t, <-result)
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 
 	natsrpc "github.com/evrone/go-clean-template/pkg/nats/nats_rpc"
-	"github.com/goccy/go-json"
+	"github.com/evrone/go-clean-template/pkg/rpc"
 	"github.com/nats-io/nats.go"
 )
 
@@ -19,10 +19,13 @@ const (
 
 // Client -.
 type Client struct {
-	subject    string
-	connection *nats.Conn
+	subject           string
+	subjectPerHandler bool
+	connection        *nats.Conn
 
-	timeout time.Duration
+	timeout  time.Duration
+	codec    rpc.Codec
+	connOpts []nats.Option
 }
 
 // New -.
@@ -31,20 +34,10 @@ func New(
 	serverSubject string,
 	opts ...Option,
 ) (*Client, error) {
-	connection, err := nats.Connect(
-		url,
-		nats.ReconnectWait(_defaultWaitTime),
-		nats.MaxReconnects(_defaultAttempts),
-		nats.Timeout(_defaultWaitTime),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("nats_rpc client - NewClient - nats.Connect: %w", err)
-	}
-
 	c := &Client{
-		subject:    serverSubject,
-		connection: connection,
-		timeout:    _defaultTimeout,
+		subject: serverSubject,
+		timeout: _defaultTimeout,
+		codec:   rpc.JSON,
 	}
 
 	// Custom options
@@ -52,6 +45,18 @@ func New(
 		opt(c)
 	}
 
+	connection, err := nats.Connect(
+		url,
+		append([]nats.Option{
+			nats.ReconnectWait(_defaultWaitTime),
+			nats.MaxReconnects(_defaultAttempts),
+			nats.Timeout(_defaultWaitTime),
+		}, c.connOpts...)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nats_rpc client - NewClient - nats.Connect: %w", err)
+	}
+
 	c.connection = connection
 
 	return c, nil
@@ -66,25 +71,25 @@ func (c *Client) Shutdown() error {
 
 // RemoteCall -.
 func (c *Client) RemoteCall(handler string, request, response interface{}) error {
-	var (
-		requestBody []byte
-		err         error
-	)
+	req, err := rpc.NewRequest(c.codec, handler, request)
+	if err != nil {
+		return fmt.Errorf("nats_rpc client - Client - RemoteCall - rpc.NewRequest: %w", err)
+	}
 
-	if request != nil {
-		requestBody, err = json.Marshal(request)
-		if err != nil {
-			return err
-		}
+	req.SetDeadline(time.Now().Add(c.timeout))
+
+	subject := c.subject
+	if c.subjectPerHandler {
+		subject = natsrpc.HandlerSubject(c.subject, handler)
 	}
 
 	requestMessage := nats.Msg{
-		Subject: c.subject,
-		Header: nats.Header{
-			"Handler": []string{handler},
-		},
-		Data: requestBody,
+		Subject: subject,
+		Header:  natsrpc.Header(req.Header),
+		Data:    req.Body,
 	}
+	requestMessage.Header.Set(natsrpc.HeaderHandler, req.Handler)
+	requestMessage.Header.Set(natsrpc.HeaderContentType, req.ContentType)
 
 	message, err := c.connection.RequestMsg(&requestMessage, c.timeout)
 	if errors.Is(err, context.DeadlineExceeded) {
@@ -95,17 +100,12 @@ func (c *Client) RemoteCall(handler string, request, response interface{}) error
 		return fmt.Errorf("nats_rpc client - Client - RemoteCall - c.connection.Conn.Request: %w", err)
 	}
 
-	switch message.Header.Get("Status") {
-	case natsrpc.Success:
-		err = json.Unmarshal(message.Data, &response)
-		if err != nil {
-			return fmt.Errorf("nats_rpc client - Client - RemoteCall - json.Unmarshal: %w", err)
-		}
-	case natsrpc.ErrBadHandler.Error():
-		return natsrpc.ErrBadHandler
-	case natsrpc.ErrInternalServer.Error():
-		return natsrpc.ErrInternalServer
+	resp := rpc.Response{
+		Status:      message.Header.Get(natsrpc.HeaderStatus),
+		ContentType: message.Header.Get(natsrpc.HeaderContentType),
+		Header:      natsrpc.Headers(message.Header),
+		Body:        message.Data,
 	}
 
-	return nil
+	return resp.Decode(response)
 }
@@ -1,6 +1,11 @@
 package client
 
-import "time"
+import (
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/nats-io/nats.go"
+)
 
 // Option -.
 type Option func(*Client)
@@ -11,3 +16,25 @@ func Timeout(timeout time.Duration) Option {
 		c.timeout = timeout
 	}
 }
+
+// SubjectPerHandler sends each call to the handler's own subject (<server subject>.<handler>),
+// matching servers started with server.SubjectPerHandler.
+func SubjectPerHandler(enabled bool) Option {
+	return func(c *Client) {
+		c.subjectPerHandler = enabled
+	}
+}
+
+// Codec encodes requests, e.g. rpc.Protobuf; the server replies with the same codec. JSON by default.
+func Codec(codec rpc.Codec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// ConnOptions adds nats.Connect options, e.g. nats.Secure, nats.UserCredentials or nats.Nkey.
+func ConnOptions(opts ...nats.Option) Option {
+	return func(c *Client) {
+		c.connOpts = append(c.connOpts, opts...)
+	}
+}
@@ -0,0 +1,97 @@
+package natsrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	_defaultWaitTime = 5 * time.Second
+	_defaultAttempts = 10
+)
+
+// ErrDrainTimeout -.
+var ErrDrainTimeout = errors.New("nats_rpc - Conn - Drain - drain timeout")
+
+// Conn is the connection of RPC servers. It gives handlers a context that outlives the drain,
+// so requests received before shutdown complete with a live context.
+type Conn struct {
+	*nats.Conn
+
+	// ctx is the context of handlers, canceled once in-flight requests are done on Drain.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	closed       chan struct{}
+	drainTimeout time.Duration
+}
+
+// Connect connects to url with the reconnect defaults of RPC servers; opts are applied last.
+func Connect(url string, drainTimeout time.Duration, opts ...nats.Option) (*Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Conn{
+		ctx:          ctx,
+		cancel:       cancel,
+		closed:       make(chan struct{}),
+		drainTimeout: drainTimeout,
+	}
+
+	connection, err := nats.Connect(
+		url,
+		append([]nats.Option{
+			nats.ReconnectWait(_defaultWaitTime),
+			nats.MaxReconnects(_defaultAttempts),
+			nats.Timeout(_defaultWaitTime),
+			nats.DrainTimeout(drainTimeout),
+			nats.ClosedHandler(func(_ *nats.Conn) {
+				close(c.closed)
+			}),
+		}, opts...)...,
+	)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("nats.Connect: %w", err)
+	}
+
+	c.Conn = connection
+
+	return c, nil
+}
+
+// Context returns the context of handlers.
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Drain drains subscriptions so in-flight requests complete, waits until the connection
+// is closed and then cancels the context of handlers.
+func (c *Conn) Drain() error {
+	defer c.cancel()
+
+	err := c.Conn.Drain()
+	if err != nil {
+		c.Close()
+
+		return fmt.Errorf("c.Conn.Drain: %w", err)
+	}
+
+	// The connection enforces drainTimeout itself, the extra wait only guards against a lost callback.
+	timeout := time.NewTimer(c.drainTimeout + _defaultWaitTime)
+	defer timeout.Stop()
+
+	select {
+	case <-c.closed:
+	case <-timeout.C:
+		c.Close()
+
+		return ErrDrainTimeout
+	}
+
+	return nil
+}
@@ -1,15 +1,25 @@
 package natsrpc
 
-import "errors"
+import "github.com/evrone/go-clean-template/pkg/rpc"
 
+// Errors and statuses are shared by all RPC transports, so errors.Is works the same on every bus.
 var (
 	// ErrTimeout -.
-	ErrTimeout = errors.New("timeout")
+	ErrTimeout = rpc.ErrTimeout
 	// ErrInternalServer -.
-	ErrInternalServer = errors.New("internal server error")
+	ErrInternalServer = rpc.ErrInternalServer
 	// ErrBadHandler -.
-	ErrBadHandler = errors.New("unregistered handler")
+	ErrBadHandler = rpc.ErrBadHandler
 )
 
-// Success -.
-const Success = "success"
+const (
+	// Success -.
+	Success = rpc.Success
+
+	// HeaderHandler selects the handler when calls share one subject.
+	HeaderHandler = "Handler"
+	// HeaderStatus carries the reply status.
+	HeaderStatus = "Status"
+	// HeaderContentType selects the codec of the body, JSON if it is absent.
+	HeaderContentType = "Content-Type"
+)
@@ -0,0 +1,23 @@
+package natsrpc
+
+import "github.com/nats-io/nats.go"
+
+// Headers converts NATS headers to rpc headers, keeping the first value of every key.
+func Headers(header nats.Header) map[string]string {
+	h := make(map[string]string, len(header))
+	for k := range header {
+		h[k] = header.Get(k)
+	}
+
+	return h
+}
+
+// Header converts rpc headers to NATS headers.
+func Header(h map[string]string) nats.Header {
+	header := make(nats.Header, len(h))
+	for k, v := range h {
+		header.Set(k, v)
+	}
+
+	return header
+}
@@ -1,6 +1,10 @@
 package server
 
-import "time"
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
 
 // Option -.
 type Option func(*Server)
@@ -11,3 +15,34 @@ func Timeout(timeout time.Duration) Option {
 		s.timeout = timeout
 	}
 }
+
+// QueueGroup subscribes handlers as members of the given queue group, so NATS
+// load-balances requests across all server instances instead of fanning them out.
+func QueueGroup(queue string) Option {
+	return func(s *Server) {
+		s.queue = queue
+	}
+}
+
+// SubjectPerHandler subscribes every handler on its own subject
+// (<server subject>.<handler>, e.g. rpc_server.v1.getHistory) instead of
+// dispatching by the Handler header on a single shared subject.
+func SubjectPerHandler(enabled bool) Option {
+	return func(s *Server) {
+		s.subjectPerHandler = enabled
+	}
+}
+
+// DrainTimeout -.
+func DrainTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// ConnOptions adds nats.Connect options, e.g. nats.Secure, nats.UserCredentials or nats.Nkey.
+func ConnOptions(opts ...nats.Option) Option {
+	return func(s *Server) {
+		s.connOpts = append(s.connOpts, opts...)
+	}
+}
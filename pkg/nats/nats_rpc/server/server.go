@@ -9,33 +9,35 @@ import (
 
 	"github.com/evrone/go-clean-template/pkg/logger"
 	natsrpc "github.com/evrone/go-clean-template/pkg/nats/nats_rpc"
-	"github.com/goccy/go-json"
+	"github.com/evrone/go-clean-template/pkg/rpc"
 	"github.com/nats-io/nats.go"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	_defaultWaitTime = 5 * time.Second
-	_defaultAttempts = 10
-	_defaultTimeout  = 2 * time.Second
+	_defaultTimeout      = 2 * time.Second
+	_defaultDrainTimeout = 10 * time.Second
 )
 
-// CallHandler -.
-type CallHandler func(*nats.Msg) (interface{}, error)
+// ErrDrainTimeout -.
+var ErrDrainTimeout = natsrpc.ErrDrainTimeout
 
 // Server -.
 type Server struct {
 	ctx context.Context
 	eg  *errgroup.Group
 
-	subject      string
-	connection   *nats.Conn
-	subscription *nats.Subscription
-	router       map[string]CallHandler
-	stop         chan struct{}
-	notify       chan error
+	subject           string
+	queue             string
+	subjectPerHandler bool
+	connection        *natsrpc.Conn
+	router            *rpc.Router
+	stop              chan struct{}
+	notify            chan error
 
-	timeout time.Duration
+	timeout      time.Duration
+	drainTimeout time.Duration
+	connOpts     []nats.Option
 
 	logger logger.Interface
 }
@@ -44,33 +46,23 @@ type Server struct {
 func New(
 	url,
 	serverSubject string,
-	router map[string]CallHandler,
+	router *rpc.Router,
 	l logger.Interface,
 	opts ...Option,
 ) (*Server, error) {
 	group, ctx := errgroup.WithContext(context.Background())
 	group.SetLimit(1) // Run only one goroutine
 
-	connection, err := nats.Connect(
-		url,
-		nats.ReconnectWait(_defaultWaitTime),
-		nats.MaxReconnects(_defaultAttempts),
-		nats.Timeout(_defaultWaitTime),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("nats_rpc server - NewServer - nats.Connect: %w", err)
-	}
-
 	s := &Server{
-		ctx:        ctx,
-		eg:         group,
-		subject:    serverSubject,
-		connection: connection,
-		router:     router,
-		stop:       make(chan struct{}),
-		notify:     make(chan error, 1),
-		timeout:    _defaultTimeout,
-		logger:     l,
+		ctx:          ctx,
+		eg:           group,
+		subject:      serverSubject,
+		router:       router,
+		stop:         make(chan struct{}),
+		notify:       make(chan error, 1),
+		timeout:      _defaultTimeout,
+		drainTimeout: _defaultDrainTimeout,
+		logger:       l,
 	}
 
 	// Custom options
@@ -78,6 +70,13 @@ func New(
 		opt(s)
 	}
 
+	connection, err := natsrpc.Connect(url, s.drainTimeout, s.connOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("nats_rpc server - NewServer - natsrpc.Connect: %w", err)
+	}
+
+	s.connection = connection
+
 	return s, nil
 }
 
@@ -107,7 +106,7 @@ func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
-// Shutdown -.
+// Shutdown drains subscriptions so in-flight requests complete, then closes the connection.
 func (s *Server) Shutdown() error {
 	var shutdownErrors []error
 
@@ -121,70 +120,75 @@ func (s *Server) Shutdown() error {
 		shutdownErrors = append(shutdownErrors, err)
 	}
 
-	// Unsubscribe
-	if s.subscription != nil {
-		err := s.subscription.Unsubscribe()
-		if err != nil {
-			s.logger.Error(err, "nats_rpc server - Server - Shutdown - s.conn.Subscription.Unsubscribe")
+	// Drain subscriptions and close connection
+	err = s.connection.Drain()
+	if err != nil {
+		s.logger.Error(err, "nats_rpc server - Server - Shutdown - s.connection.Drain")
 
-			shutdownErrors = append(shutdownErrors, err)
-		}
+		shutdownErrors = append(shutdownErrors, err)
 	}
 
-	// Close connection
-	s.connection.Close()
-
 	s.logger.Info("nats_rpc server - Server - Shutdown")
 
 	return errors.Join(shutdownErrors...)
 }
 
 func (s *Server) subscribe() error {
-	subscription, err := s.connection.Subscribe(s.subject, s.handleMessage)
-	if err != nil {
-		return fmt.Errorf("nats_rpc server - subscribe - s.conn.AttemptConnect: %w", err)
+	if !s.subjectPerHandler {
+		return s.subscribeSubject(s.subject, func(msg *nats.Msg) {
+			s.serveCall(msg, msg.Header.Get(natsrpc.HeaderHandler))
+		})
 	}
 
-	s.subscription = subscription
+	for _, handler := range s.router.Handlers() {
+		err := s.subscribeSubject(natsrpc.HandlerSubject(s.subject, handler), func(msg *nats.Msg) {
+			s.serveCall(msg, handler)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func (s *Server) handleMessage(msg *nats.Msg) {
-	handler := msg.Header.Get("Handler")
+func (s *Server) subscribeSubject(subject string, cb nats.MsgHandler) error {
+	var err error
 
-	callHandler, ok := s.router[handler]
-	if !ok {
-		s.publish(msg, nil, natsrpc.ErrBadHandler.Error())
-
-		return
+	// Subscriptions are released by Drain on shutdown.
+	if s.queue != "" {
+		_, err = s.connection.QueueSubscribe(subject, s.queue, cb)
+	} else {
+		_, err = s.connection.Subscribe(subject, cb)
 	}
 
-	response, err := callHandler(msg)
 	if err != nil {
-		s.publish(msg, nil, natsrpc.ErrInternalServer.Error())
-
-		s.logger.Error(err, "nats_rpc server - Server - handleMessage - callHandler")
-
-		return
+		return fmt.Errorf("nats_rpc server - subscribe - s.connection.Subscribe(%s): %w", subject, err)
 	}
 
-	body, err := json.Marshal(response)
-	if err != nil {
-		s.logger.Error(err, "nats_rpc server - Server - handleMessage - json.Marshal")
-
-		s.publish(msg, nil, natsrpc.ErrInternalServer.Error())
+	return nil
+}
 
-		return
+func (s *Server) serveCall(msg *nats.Msg, handler string) {
+	response, err := s.router.Serve(s.connection.Context(), &rpc.Request{
+		Handler:     handler,
+		ContentType: msg.Header.Get(natsrpc.HeaderContentType),
+		Header:      natsrpc.Headers(msg.Header),
+		Body:        msg.Data,
+	})
+	if err != nil {
+		s.logger.Error(err, "nats_rpc server - Server - serveCall - s.router.Serve")
 	}
 
-	s.publish(msg, body, natsrpc.Success)
+	s.publish(msg, response)
 }
 
-func (s *Server) publish(msg *nats.Msg, body []byte, status string) {
+func (s *Server) publish(msg *nats.Msg, response *rpc.Response) {
 	respondMsg := nats.NewMsg(msg.Reply)
-	respondMsg.Header.Set("Status", status)
-	respondMsg.Data = body
+	respondMsg.Header = natsrpc.Header(response.Header)
+	respondMsg.Header.Set(natsrpc.HeaderStatus, response.Status)
+	respondMsg.Header.Set(natsrpc.HeaderContentType, response.ContentType)
+	respondMsg.Data = response.Body
 
 	err := s.connection.PublishMsg(respondMsg)
 	if err != nil {
@@ -0,0 +1,264 @@
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_rpc/client"
+	"github.com/evrone/go-clean-template/pkg/nats/nats_rpc/server"
+	"github.com/evrone/go-clean-template/pkg/nats/natstest"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig/tlstest"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSubject = "rpc_server"
+	testHandler = "v1.echo"
+)
+
+type echoResponse struct {
+	Data string `json:"data"`
+}
+
+func echoRouter(calls *atomic.Int64, delay time.Duration) *rpc.Router {
+	router := rpc.NewRouter()
+	router.Handle(testHandler, func(_ context.Context, req *rpc.Request) (interface{}, error) {
+		calls.Add(1)
+		time.Sleep(delay)
+
+		return echoResponse{Data: string(req.Body)}, nil
+	})
+
+	return router
+}
+
+func startServer(t *testing.T, url string, router *rpc.Router, opts ...server.Option) *server.Server {
+	t.Helper()
+
+	s, err := server.New(url, testSubject, router, logger.New("error"), opts...)
+	require.NoError(t, err)
+
+	s.Start()
+
+	return s
+}
+
+func newClient(t *testing.T, url string, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	c, err := client.New(url, testSubject, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Shutdown())
+	})
+
+	return c
+}
+
+func TestServer_QueueGroupAnswersOnce(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+
+	var calls atomic.Int64
+
+	for range 2 {
+		s := startServer(t, url, echoRouter(&calls, 0), server.QueueGroup("rpc_server"))
+
+		t.Cleanup(func() {
+			require.NoError(t, s.Shutdown())
+		})
+	}
+
+	c := newClient(t, url)
+
+	// Let both subscriptions register before publishing.
+	time.Sleep(100 * time.Millisecond)
+
+	const requests = 20
+
+	for range requests {
+		var resp echoResponse
+
+		err := c.RemoteCall(testHandler, "ping", &resp)
+		require.NoError(t, err)
+		require.Equal(t, `"ping"`, resp.Data)
+	}
+
+	require.EqualValues(t, requests, calls.Load())
+}
+
+func TestServer_SubjectPerHandler(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+
+	var calls atomic.Int64
+
+	s := startServer(t, url, echoRouter(&calls, 0), server.SubjectPerHandler(true))
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	var resp echoResponse
+
+	err := newClient(t, url, client.SubjectPerHandler(true)).RemoteCall(testHandler, "ping", &resp)
+	require.NoError(t, err)
+	require.Equal(t, `"ping"`, resp.Data)
+
+	// The shared subject is not subscribed in this mode.
+	err = newClient(t, url).RemoteCall(testHandler, "ping", &resp)
+	require.ErrorIs(t, err, nats.ErrNoResponders)
+}
+
+func TestServer_ShutdownDrainsInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+
+	var calls atomic.Int64
+
+	s := startServer(t, url, echoRouter(&calls, 300*time.Millisecond))
+	c := newClient(t, url, client.Timeout(2*time.Second))
+
+	time.Sleep(100 * time.Millisecond)
+
+	result := make(chan error, 1)
+
+	go func() {
+		var resp echoResponse
+
+		result <- c.RemoteCall(testHandler, "ping", &resp)
+	}()
+
+	// Shut down while the handler is still running.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown())
+	require.NoError(t, <-result)
+}
+
+func TestServer_ShutdownKeepsHandlerContext(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+
+	var calls atomic.Int64
+
+	router := rpc.NewRouter()
+	router.Handle(testHandler, func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+		calls.Add(1)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(300 * time.Millisecond):
+		}
+
+		return echoResponse{Data: string(req.Body)}, ctx.Err()
+	})
+
+	s := startServer(t, url, router)
+	c := newClient(t, url, client.Timeout(2*time.Second))
+
+	time.Sleep(100 * time.Millisecond)
+
+	result := make(chan error, 1)
+
+	go func() {
+		var resp echoResponse
+
+		result <- c.RemoteCall(testHandler, "ping", &resp)
+	}()
+
+	// The handler context must outlive the shutdown until the request is answered.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Shutdown())
+	require.NoError(t, <-result)
+}
+
+func TestServer_PropagatesTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	url := natstest.Run(t)
+	errNotFound := &rpc.Error{Code: "NOT_FOUND", Message: "resource not found"}
+
+	router := rpc.NewRouter()
+	router.Handle(testHandler, func(context.Context, *rpc.Request) (interface{}, error) {
+		return nil, fmt.Errorf("repo - Get: %w", errNotFound)
+	})
+
+	s := startServer(t, url, router)
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	var resp echoResponse
+
+	err := newClient(t, url).RemoteCall(testHandler, "ping", &resp)
+	require.ErrorIs(t, err, errNotFound)
+	require.EqualError(t, err, "resource not found")
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+
+	opts := natstest.Options()
+	opts.TLS = true
+	opts.TLSVerify = true
+	opts.TLSConfig = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{ca.Issue(t, "nats").TLS},
+		ClientCAs:    ca.Pool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+
+	url := natstest.RunWithOptions(t, opts)
+
+	clientTLS := func(name string) *tls.Config {
+		cert := ca.Issue(t, name)
+
+		cfg, err := tlsconfig.Client(tlsconfig.CAFile(ca.CertFile), tlsconfig.Certificate(cert.CertFile, cert.KeyFile))
+		require.NoError(t, err)
+
+		return cfg
+	}
+
+	var calls atomic.Int64
+
+	s := startServer(t, url, echoRouter(&calls, 0), server.ConnOptions(nats.Secure(clientTLS("server"))))
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown())
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	var resp echoResponse
+
+	err := newClient(t, url, client.ConnOptions(nats.Secure(clientTLS("client")))).RemoteCall(testHandler, "ping", &resp)
+	require.NoError(t, err)
+	require.Equal(t, `"ping"`, resp.Data)
+
+	// Without a client certificate the handshake is rejected.
+	noCert, err := tlsconfig.Client(tlsconfig.CAFile(ca.CertFile))
+	require.NoError(t, err)
+
+	_, err = client.New(url, testSubject, client.ConnOptions(nats.Secure(noCert), nats.MaxReconnects(0)))
+	require.Error(t, err)
+}
@@ -0,0 +1,6 @@
+package natsrpc
+
+// HandlerSubject returns the dedicated subject of a handler for servers running with one subject per handler.
+func HandlerSubject(serverSubject, handler string) string {
+	return serverSubject + "." + handler
+}
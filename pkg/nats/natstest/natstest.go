@@ -0,0 +1,54 @@
+// Package natstest runs embedded NATS servers for tests.
+package natstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+const _readyTimeout = 5 * time.Second
+
+// Options returns the options of Run: a random port on 127.0.0.1, without logs and signal handlers.
+func Options() *server.Options {
+	return &server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true}
+}
+
+// Run starts an embedded NATS server, shut down at the end of the test, and returns its client URL.
+func Run(t testing.TB) string {
+	t.Helper()
+
+	return RunWithOptions(t, Options())
+}
+
+// RunJetStream is Run with JetStream enabled, storing streams in a test temp dir.
+func RunJetStream(t testing.TB) string {
+	t.Helper()
+
+	opts := Options()
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+
+	return RunWithOptions(t, opts)
+}
+
+// RunWithOptions is Run with the given server options.
+func RunWithOptions(t testing.TB, opts *server.Options) string {
+	t.Helper()
+
+	ns, err := server.NewServer(opts)
+	if err != nil {
+		t.Fatalf("natstest - server.NewServer: %s", err)
+	}
+
+	go ns.Start()
+
+	if !ns.ReadyForConnections(_readyTimeout) {
+		t.Fatal("natstest - embedded NATS server is not ready")
+	}
+
+	t.Cleanup(ns.Shutdown)
+
+	return ns.ClientURL()
+}
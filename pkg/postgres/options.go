@@ -1,6 +1,9 @@
 package postgres
 
-import "time"
+import (
+	"crypto/tls"
+	"time"
+)
 
 // Option -.
 type Option func(*Postgres)
@@ -25,3 +28,11 @@ func ConnTimeout(timeout time.Duration) Option {
 		c.connTimeout = timeout
 	}
 }
+
+// TLSConfig connects over TLS with cfg, overriding the sslmode of the URL.
+// The server name defaults to the host of the URL.
+func TLSConfig(cfg *tls.Config) Option {
+	return func(c *Postgres) {
+		c.tlsConfig = cfg
+	}
+}
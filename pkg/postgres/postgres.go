@@ -3,6 +3,7 @@ package postgres
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"time"
@@ -22,6 +23,7 @@ type Postgres struct {
 	maxPoolSize  int
 	connAttempts int
 	connTimeout  time.Duration
+	tlsConfig    *tls.Config
 
 	Builder squirrel.StatementBuilderType
 	Pool    *pgxpool.Pool
@@ -49,6 +51,17 @@ func New(url string, opts ...Option) (*Postgres, error) {
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize) //nolint:gosec // skip integer overflow conversion int -> int32
 
+	if pg.tlsConfig != nil {
+		tlsConfig := pg.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = poolConfig.ConnConfig.Host
+		}
+
+		// No plaintext fallbacks (sslmode=prefer).
+		poolConfig.ConnConfig.TLSConfig = tlsConfig
+		poolConfig.ConnConfig.Fallbacks = nil
+	}
+
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
 		if err == nil {
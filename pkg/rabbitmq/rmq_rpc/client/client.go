@@ -8,35 +8,46 @@ import (
 	"time"
 
 	rmqrpc "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc"
-	"github.com/goccy/go-json"
+	"github.com/evrone/go-clean-template/pkg/rpc"
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"golang.org/x/sync/errgroup"
 )
 
-// ErrConnectionClosed -.
-var ErrConnectionClosed = errors.New("rmq_rpc client - Client - RemoteCall - Connection closed")
+var (
+	// ErrConnectionClosed -.
+	ErrConnectionClosed = errors.New("rmq_rpc client - Client - RemoteCall - Connection closed")
+	// ErrConnectionLost is returned while the client reconnects; the call can be retried.
+	ErrConnectionLost = errors.New("rmq_rpc client - Client - RemoteCall - Connection lost")
+	// ErrReconnectFailed is returned once the client ran out of reconnect attempts; the client must be recreated.
+	ErrReconnectFailed = errors.New("rmq_rpc client - Client - RemoteCall - reconnect failed")
+	// ErrNotConfirmed is returned when the broker nacks the request or does not confirm it in time.
+	ErrNotConfirmed = errors.New("rmq_rpc client - Client - RemoteCall - publish not confirmed")
+)
 
 const (
-	_defaultWaitTime = 5 * time.Second
-	_defaultAttempts = 10
-	_defaultTimeout  = 2 * time.Second
+	_defaultWaitTime    = time.Second
+	_defaultMaxWaitTime = 30 * time.Second
+	_defaultAttempts    = 10
+	_defaultTimeout     = 2 * time.Second
 )
 
 // Message -.
 type Message struct {
 	Queue         string
+	Type          string
 	Priority      uint8
 	ContentType   string
+	Headers       amqp.Table
 	Body          []byte
 	ReplyTo       string
 	CorrelationID string
 }
 
 type pendingCall struct {
-	done   chan struct{}
-	status string
-	body   []byte
+	done     chan struct{}
+	response rpc.Response
+	err      error
 }
 
 // Client -.
@@ -46,13 +57,19 @@ type Client struct {
 
 	conn           *rmqrpc.Connection
 	serverExchange string
-	notify         chan error
 	stop           chan struct{}
 
+	// connMu guards conn between reconnects, connErr is set while the consumer is down
+	// and keeps ErrReconnectFailed once reconnect attempts run out.
+	connMu  sync.RWMutex
+	connErr error
+
 	rw    sync.RWMutex
 	calls map[string]*pendingCall
 
-	timeout time.Duration
+	timeout     time.Duration
+	confirmWait time.Duration
+	codec       rpc.Codec
 }
 
 // New -.
@@ -61,9 +78,10 @@ func New(url, serverExchange, clientExchange string, opts ...Option) (*Client, e
 	group.SetLimit(1) // Run only one goroutine
 
 	cfg := rmqrpc.Config{
-		URL:      url,
-		WaitTime: _defaultWaitTime,
-		Attempts: _defaultAttempts,
+		URL:         url,
+		WaitTime:    _defaultWaitTime,
+		MaxWaitTime: _defaultMaxWaitTime,
+		Attempts:    _defaultAttempts,
 	}
 
 	c := &Client{
@@ -71,10 +89,10 @@ func New(url, serverExchange, clientExchange string, opts ...Option) (*Client, e
 		eg:             group,
 		conn:           rmqrpc.New(clientExchange, cfg),
 		serverExchange: serverExchange,
-		notify:         make(chan error),
 		stop:           make(chan struct{}),
 		calls:          make(map[string]*pendingCall),
 		timeout:        _defaultTimeout,
+		codec:          rpc.JSON,
 	}
 
 	// Custom options
@@ -98,159 +116,207 @@ func (c *Client) Shutdown() error {
 
 	close(c.stop)
 
-	// Wait for all goroutines to finish and get any error
-	err := c.eg.Wait()
-	if err != nil && !errors.Is(err, context.Canceled) {
+	// Close connection first, it also interrupts a reconnect in progress
+	err := c.conn.Close()
+	if err != nil {
 		shutdownErrors = append(shutdownErrors, err)
 	}
 
-	// Close connection
-
-	err = c.conn.Connection.Close()
-	if err != nil {
+	// Wait for all goroutines to finish and get any error
+	err = c.eg.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
 		shutdownErrors = append(shutdownErrors, err)
 	}
 
 	return errors.Join(shutdownErrors...)
 }
 
-// RemoteCall -.
-func (c *Client) RemoteCall(handler string, request, response interface{}) error {
-	err := c.preRemoteCallWait()
-	if err != nil {
-		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.preWait: %w", err)
+// RemoteCall sends request to handler and decodes the reply into response.
+// The call is bounded by ctx and by the client timeout, see CallTimeout.
+func (c *Client) RemoteCall(ctx context.Context, handler string, request, response interface{}, opts ...CallOption) error {
+	o := callOptions{timeout: c.timeout}
+
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	corrID := uuid.New().String()
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
 
-	err = c.publish(corrID, handler, request)
+	req, err := rpc.NewRequest(c.codec, handler, request)
 	if err != nil {
-		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.publish: %w", err)
+		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - rpc.NewRequest: %w", err)
 	}
 
+	deadline, _ := ctx.Deadline()
+	req.SetDeadline(deadline)
+
+	corrID := uuid.New().String()
 	call := &pendingCall{done: make(chan struct{})}
 
+	// Register before publishing, so a fast reply is not dropped.
 	c.addCall(corrID, call)
 	defer c.deleteCall(corrID)
 
-	err = c.remoteCallWait(call)
+	err = c.publish(ctx, Message{
+		Queue:         c.serverExchange,
+		Type:          req.Handler,
+		Priority:      o.priority,
+		ContentType:   req.ContentType,
+		Headers:       rmqrpc.Table(req.Header),
+		Body:          req.Body,
+		ReplyTo:       c.conn.ReplyTo(),
+		CorrelationID: corrID,
+	})
 	if err != nil {
-		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.remoteCallWait: %w", err)
+		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.publish: %w", err)
 	}
 
-	switch call.status {
-	case rmqrpc.Success:
-		err = json.Unmarshal(call.body, &response)
-		if err != nil {
-			return fmt.Errorf("rmq_rpc client - Client - RemoteCall - json.Unmarshal: %w", err)
-		}
-
-		return nil
-	case rmqrpc.ErrBadHandler.Error():
-		return rmqrpc.ErrBadHandler
-	case rmqrpc.ErrInternalServer.Error():
-		return rmqrpc.ErrInternalServer
+	err = c.remoteCallWait(ctx, call)
+	if err != nil {
+		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.remoteCallWait: %w", err)
 	}
 
-	return nil
+	return call.response.Decode(response)
 }
 
-func (c *Client) preRemoteCallWait() error {
+func (c *Client) remoteCallWait(ctx context.Context, call *pendingCall) error {
 	select {
-	case <-c.ctx.Done():
-		return c.ctx.Err()
-	case err := <-c.notify:
-		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.notify: %w", err)
-	case <-c.stop:
-		return ErrConnectionClosed
-	default:
-	}
-
-	return nil
-}
-
-func (c *Client) remoteCallWait(call *pendingCall) error {
-	timeout := time.NewTimer(c.timeout)
-	defer timeout.Stop()
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %w", rmqrpc.ErrTimeout, ctx.Err())
+		}
 
-	select {
-	case <-c.ctx.Done():
-		return c.ctx.Err()
-	case err := <-c.notify:
-		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.notify: %w", err)
+		return ctx.Err()
 	case <-c.stop:
 		return ErrConnectionClosed
-	case <-timeout.C:
-		return rmqrpc.ErrTimeout
 	case <-call.done:
 	}
 
-	return nil
+	return call.err
 }
 
 func (c *Client) start() {
-	c.eg.Go(func() error {
-		err := c.handleMessages()
-		if err != nil {
-			c.notify <- err
-
-			close(c.notify)
-
-			return err
-		}
-
-		return nil
-	})
+	c.eg.Go(c.handleMessages)
 }
 
 func (c *Client) handleMessages() error {
 	for {
+		var err error
+
 		select {
 		case <-c.ctx.Done():
 			return c.ctx.Err()
 		case <-c.stop:
 			return nil
+		case <-c.conn.Done():
+			err = c.reconnect()
 		case d, opened := <-c.conn.Delivery:
 			if !opened {
-				err := c.reconnect()
-				if err != nil {
-					return err
-				}
+				err = c.reconnect()
 
 				break
 			}
 
 			c.serveCall(&d)
+		case r, opened := <-c.conn.Return: // nil unless Mandatory
+			if !opened {
+				err = c.reconnect()
+
+				break
+			}
+
+			c.failCall(r.CorrelationId, fmt.Errorf("%w: %s", rmqrpc.ErrUnroutable, r.ReplyText))
+		}
+
+		if err != nil {
+			return err
 		}
 	}
 }
 
+// reconnect fails the calls waiting for replies, which are lost with the exclusive queue,
+// and connects again with the configured attempts and backoff. When they run out, calls fail
+// with ErrReconnectFailed and the error is returned.
 func (c *Client) reconnect() error {
-	return c.conn.AttemptConnect()
+	c.setConnErr(ErrConnectionLost)
+	c.failCalls(ErrConnectionLost)
+
+	err := c.conn.Reconnect()
+	if errors.Is(err, rmqrpc.ErrClosed) {
+		return nil
+	}
+
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrReconnectFailed, err)
+	}
+
+	c.setConnErr(err)
+
+	if err != nil {
+		return fmt.Errorf("rmq_rpc client - Client - reconnect - c.conn.Reconnect: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) setConnErr(err error) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	c.connErr = err
 }
 
 func (c *Client) serveCall(d *amqp.Delivery) {
 	defer c.ack(d, false)
 
-	call, ok := c.getCall(d.CorrelationId)
+	call, ok := c.takeCall(d.CorrelationId)
 	if !ok {
 		return
 	}
 
-	call.status = d.Type
-	call.body = d.Body
+	call.response = rpc.Response{
+		Status:      d.Type,
+		ContentType: d.ContentType,
+		Header:      rmqrpc.Headers(d.Headers),
+		Body:        d.Body,
+	}
 	close(call.done)
 }
 
-func (c *Client) getCall(corrID string) (*pendingCall, bool) {
-	c.rw.RLock()
-	defer c.rw.RUnlock()
+// takeCall removes the call, so it is completed only once.
+func (c *Client) takeCall(corrID string) (*pendingCall, bool) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
 
 	call, ok := c.calls[corrID]
+	delete(c.calls, corrID)
 
 	return call, ok
 }
 
+func (c *Client) failCall(corrID string, err error) {
+	call, ok := c.takeCall(corrID)
+	if !ok {
+		return
+	}
+
+	call.err = err
+	close(call.done)
+}
+
+func (c *Client) failCalls(err error) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	for corrID, call := range c.calls {
+		call.err = err
+		close(call.done)
+
+		delete(c.calls, corrID)
+	}
+}
+
 func (c *Client) addCall(corrID string, call *pendingCall) {
 	c.rw.Lock()
 	defer c.rw.Unlock()
@@ -266,38 +332,68 @@ func (c *Client) deleteCall(corrID string) {
 }
 
 func (c *Client) ack(d *amqp.Delivery, multiple bool) {
+	// Direct reply-to deliveries are consumed in no-ack mode.
+	if c.conn.DirectReplyTo {
+		return
+	}
+
 	d.Ack(multiple) //nolint:errcheck // we can't do anything with this error
 }
 
-func (c *Client) publish(corrID, handler string, request interface{}) error {
-	var (
-		requestBody []byte
-		err         error
-	)
+// publish sends msg and, in publisher confirm mode, waits up to confirmWait for the broker ack.
+func (c *Client) publish(ctx context.Context, msg Message) error {
+	confirmation, err := c.publishMessage(ctx, msg)
+	if err != nil {
+		return err
+	}
 
-	if request != nil {
-		requestBody, err = json.Marshal(request)
-		if err != nil {
-			return err
-		}
+	// nil unless the channel is in confirm mode
+	if confirmation == nil {
+		return nil
 	}
 
-	err = c.conn.Channel.Publish(
-		c.serverExchange,
+	ctx, cancel := context.WithTimeout(ctx, c.confirmWait)
+	defer cancel()
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotConfirmed, err)
+	}
+
+	if !acked {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
+
+func (c *Client) publishMessage(ctx context.Context, msg Message) (*amqp.DeferredConfirmation, error) {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+
+	if c.connErr != nil {
+		return nil, c.connErr
+	}
+
+	confirmation, err := c.conn.Channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		msg.Queue,
 		"",
-		false,
+		c.conn.Mandatory,
 		false,
 		amqp.Publishing{
-			ContentType:   "application/json",
-			CorrelationId: corrID,
-			ReplyTo:       c.conn.ConsumerExchange,
-			Type:          handler,
-			Body:          requestBody,
+			ContentType:   msg.ContentType,
+			Headers:       msg.Headers,
+			Priority:      msg.Priority,
+			CorrelationId: msg.CorrelationID,
+			ReplyTo:       msg.ReplyTo,
+			Type:          msg.Type,
+			Body:          msg.Body,
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("c.Channel.Publish: %w", err)
+		return nil, fmt.Errorf("c.Channel.PublishWithDeferredConfirmWithContext: %w", err)
 	}
 
-	return nil
+	return confirmation, nil
 }
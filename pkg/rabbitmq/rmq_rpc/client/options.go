@@ -1,6 +1,13 @@
 package client
 
-import "time"
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/logger"
+	rmqrpc "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc"
+	"github.com/evrone/go-clean-template/pkg/rpc"
+)
 
 // Option -.
 type Option func(*Client)
@@ -19,9 +26,100 @@ func ConnWaitTime(timeout time.Duration) Option {
 	}
 }
 
+// ConnMaxWaitTime caps the exponential reconnect backoff.
+func ConnMaxWaitTime(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.conn.MaxWaitTime = timeout
+	}
+}
+
+// TLS configures the CA bundle and client certificate for amqps:// URLs, see pkg/tlsconfig.
+func TLS(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.conn.TLS = cfg
+	}
+}
+
+// ExternalAuth authenticates with SASL EXTERNAL by the TLS client certificate instead of the URL credentials.
+func ExternalAuth(enabled bool) Option {
+	return func(c *Client) {
+		c.conn.ExternalAuth = enabled
+	}
+}
+
 // ConnAttempts -.
 func ConnAttempts(attempts int) Option {
 	return func(c *Client) {
 		c.conn.Attempts = attempts
 	}
 }
+
+// Logger reports connection failures and reconnects, nothing is logged by default.
+func Logger(l logger.Interface) Option {
+	return func(c *Client) {
+		c.conn.Logger = l
+	}
+}
+
+// OnStateChange is called when the connection is lost, restored, given up once the attempts run out
+// or closed, e.g. to report health.
+func OnStateChange(fn func(rmqrpc.State)) Option {
+	return func(c *Client) {
+		c.conn.OnStateChange = fn
+	}
+}
+
+// Codec encodes requests, e.g. rpc.Protobuf; the server replies with the same codec. JSON by default.
+func Codec(codec rpc.Codec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// DirectReplyTo receives replies via the amq.rabbitmq.reply-to pseudo-queue,
+// so the client does not declare its own exchange and queue.
+func DirectReplyTo(enabled bool) Option {
+	return func(c *Client) {
+		c.conn.DirectReplyTo = enabled
+	}
+}
+
+// PublisherConfirms enables publisher confirms; RemoteCall waits up to wait for the broker ack.
+// Zero disables confirms.
+func PublisherConfirms(wait time.Duration) Option {
+	return func(c *Client) {
+		c.conn.Confirm = wait > 0
+		c.confirmWait = wait
+	}
+}
+
+// Mandatory publishes requests with the mandatory flag, so RemoteCall fails fast
+// with rmqrpc.ErrUnroutable when no server queue is bound instead of timing out.
+func Mandatory(enabled bool) Option {
+	return func(c *Client) {
+		c.conn.Mandatory = enabled
+	}
+}
+
+// CallOption configures a single RemoteCall.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout  time.Duration
+	priority uint8
+}
+
+// CallTimeout overrides the client timeout for one call.
+func CallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// CallPriority sets the AMQP message priority, from 0 to the x-max-priority of the server queue (see server.MaxPriority);
+// higher values are treated as the maximum. Without x-max-priority the priority is ignored.
+func CallPriority(priority uint8) CallOption {
+	return func(o *callOptions) {
+		o.priority = priority
+	}
+}
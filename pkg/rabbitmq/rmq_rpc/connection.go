@@ -1,18 +1,74 @@
 package rmqrpc
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"math/rand/v2"
+	"sync"
 	"time"
 
+	"github.com/evrone/go-clean-template/pkg/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// DirectReplyTo is the RabbitMQ pseudo-queue for RPC replies without a per-client exchange and queue.
+const DirectReplyTo = "amq.rabbitmq.reply-to"
+
+// ErrClosed is returned by AttemptConnect and Reconnect after Close.
+var ErrClosed = errors.New("rmq_rpc - Connection - closed")
+
+// State is the connection state reported to Config.OnStateChange.
+type State int
+
+// Connection states.
+const (
+	StateConnected State = iota
+	StateReconnecting
+	StateClosed
+	// StateFailed is set when AttemptConnect runs out of attempts.
+	StateFailed
+)
+
+// String -.
+func (s State) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	case StateFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
 // Config -.
 type Config struct {
-	URL      string
-	WaitTime time.Duration
-	Attempts int
+	URL string
+	// WaitTime is the first reconnect delay, doubled after every failed attempt up to MaxWaitTime.
+	WaitTime    time.Duration
+	MaxWaitTime time.Duration
+	Attempts    int
+	// MaxPriority enables message priorities (x-max-priority) on the consumer queue, 0 disables them.
+	MaxPriority uint8
+	// DirectReplyTo consumes from the amq.rabbitmq.reply-to pseudo-queue instead of declaring an exchange and a queue.
+	DirectReplyTo bool
+	// Confirm puts the channel into publisher confirm mode.
+	Confirm bool
+	// Mandatory delivers publishings returned as unroutable to Return.
+	Mandatory bool
+	// TLS is used for amqps:// URLs, nil means the system roots without a client certificate.
+	TLS *tls.Config
+	// ExternalAuth authenticates with SASL EXTERNAL, i.e. by the TLS client certificate, instead of the URL credentials.
+	ExternalAuth bool
+	// OnStateChange is called on every state change, e.g. to report health. Optional, it must not block.
+	OnStateChange func(State)
+	// Logger reports connection failures and state changes, nil discards them.
+	Logger logger.Interface
 }
 
 // Connection -.
@@ -22,6 +78,12 @@ type Connection struct {
 	Connection *amqp.Connection
 	Channel    *amqp.Channel
 	Delivery   <-chan amqp.Delivery
+	Return     <-chan amqp.Return
+
+	// mu serializes connect and Close, so Close does not miss a connection opened by a reconnect.
+	mu     sync.Mutex
+	done   chan struct{}
+	closed chan struct{}
 }
 
 // New -.
@@ -29,43 +91,280 @@ func New(consumerExchange string, cfg Config) *Connection {
 	conn := &Connection{
 		ConsumerExchange: consumerExchange,
 		Config:           cfg,
+		closed:           make(chan struct{}),
 	}
 
 	return conn
 }
 
-// AttemptConnect -.
+// ReplyTo returns the reply address for publishings of this connection.
+func (c *Connection) ReplyTo() string {
+	if c.DirectReplyTo {
+		return DirectReplyTo
+	}
+
+	return c.ConsumerExchange
+}
+
+// Done is closed when the connection or the channel is closed, by the broker or by a network failure.
+// It is replaced on every reconnect.
+func (c *Connection) Done() <-chan struct{} {
+	return c.done
+}
+
+// AttemptConnect connects and declares the topology, retrying with exponential backoff and jitter.
+// Once Attempts are exhausted the state is StateFailed.
 func (c *Connection) AttemptConnect() error {
 	var err error
-	for i := c.Attempts; i > 0; i-- {
-		if err = c.connect(); err == nil {
+
+	for attempt := range c.Attempts {
+		err = c.connect()
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+
+		if err == nil {
+			c.setState(StateConnected)
+
+			return nil
+		}
+
+		if attempt == c.Attempts-1 {
 			break
 		}
 
-		log.Printf("RabbitMQ is trying to connect, attempts left: %d", i)
-		time.Sleep(c.WaitTime)
+		delay := c.backoff(attempt)
+
+		c.logger().Warn("rmq_rpc - AttemptConnect - %s, attempts left: %d, retry in %s", err, c.Attempts-attempt-1, delay)
+
+		select {
+		case <-c.closed:
+			return ErrClosed
+		case <-time.After(delay):
+		}
 	}
 
-	if err != nil {
-		return fmt.Errorf("rmq_rpc - AttemptConnect - c.connect: %w", err)
+	err = fmt.Errorf("rmq_rpc - AttemptConnect - c.connect: %w", err)
+
+	c.logger().Error(err, "rmq_rpc - Connection - %s: no attempts left", c.ConsumerExchange)
+	c.setState(StateFailed)
+
+	return err
+}
+
+// Reconnect drops the current connection and connects again, see AttemptConnect.
+func (c *Connection) Reconnect() error {
+	select {
+	case <-c.closed:
+		return ErrClosed
+	default:
+	}
+
+	c.setState(StateReconnecting)
+
+	c.mu.Lock()
+	if c.Connection != nil {
+		c.Connection.Close() //nolint:errcheck // the connection is replaced anyway
+	}
+	c.mu.Unlock()
+
+	return c.AttemptConnect()
+}
+
+// Cancel stops the consumer of the connection: deliveries already received are still sent to Delivery,
+// which is closed after them. The connection stays open, so they can be answered.
+func (c *Connection) Cancel() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Channel == nil {
+		return nil
+	}
+
+	err := c.Channel.Cancel(c.consumerTag(), false)
+	if err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("rmq_rpc - Cancel - c.Channel.Cancel: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops reconnecting and closes the connection. It is safe to call concurrently with Reconnect.
+func (c *Connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return nil
+	default:
+	}
+
+	close(c.closed)
+
+	c.setState(StateClosed)
+
+	if c.Connection == nil {
+		return nil
+	}
+
+	err := c.Connection.Close()
+	if err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("rmq_rpc - Close - c.Connection.Close: %w", err)
 	}
 
 	return nil
 }
 
+// backoff returns WaitTime * 2^attempt capped by MaxWaitTime, with equal jitter,
+// so clients that lost the broker at the same time do not reconnect at once.
+func (c *Connection) backoff(attempt int) time.Duration {
+	maxWait := max(c.MaxWaitTime, c.WaitTime)
+	delay := c.WaitTime
+
+	for range attempt {
+		if delay >= maxWait {
+			break
+		}
+
+		delay *= 2
+	}
+
+	delay = min(delay, maxWait)
+	half := delay / 2 //nolint:mnd // equal jitter
+
+	return half + rand.N(half+1) //nolint:gosec // jitter does not need a secure source
+}
+
+func (c *Connection) logger() logger.Interface {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+
+	return c.Logger
+}
+
+func (c *Connection) setState(state State) {
+	c.logger().Info("rmq_rpc - Connection - %s: %s", c.ConsumerExchange, state)
+
+	if c.OnStateChange != nil {
+		c.OnStateChange(state)
+	}
+}
+
 func (c *Connection) connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return ErrClosed
+	default:
+	}
+
 	var err error
 
-	c.Connection, err = amqp.Dial(c.URL)
+	c.Connection, err = c.dial()
 	if err != nil {
-		return fmt.Errorf("amqp.Dial: %w", err)
+		return err
 	}
 
 	c.Channel, err = c.Connection.Channel()
 	if err != nil {
+		c.Connection.Close() //nolint:errcheck // already failing
+
 		return fmt.Errorf("c.Connection.Channel: %w", err)
 	}
 
+	c.watch()
+
+	err = c.declare()
+	if err != nil {
+		c.Connection.Close() //nolint:errcheck // already failing
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *Connection) dial() (*amqp.Connection, error) {
+	if c.ExternalAuth {
+		conn, err := amqp.DialTLS_ExternalAuth(c.URL, c.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("amqp.DialTLS_ExternalAuth: %w", err)
+		}
+
+		return conn, nil
+	}
+
+	if c.TLS != nil {
+		conn, err := amqp.DialTLS(c.URL, c.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("amqp.DialTLS: %w", err)
+		}
+
+		return conn, nil
+	}
+
+	conn, err := amqp.Dial(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("amqp.Dial: %w", err)
+	}
+
+	return conn, nil
+}
+
+// watch closes done when either the connection or the channel is closed.
+func (c *Connection) watch() {
+	done := make(chan struct{})
+	connClosed := c.Connection.NotifyClose(make(chan *amqp.Error, 1))
+	channelClosed := c.Channel.NotifyClose(make(chan *amqp.Error, 1))
+
+	go func() {
+		var err *amqp.Error
+
+		select {
+		case err = <-connClosed:
+		case err = <-channelClosed:
+		}
+
+		// nil on graceful close
+		if err != nil {
+			c.logger().Warn("rmq_rpc - Connection - %s closed: %s", c.ConsumerExchange, err)
+		}
+
+		close(done)
+	}()
+
+	c.done = done
+}
+
+// declare sets up the channel mode, exchanges, queues, bindings and consumer; it runs on every (re)connect.
+func (c *Connection) declare() error {
+	var err error
+
+	if c.Confirm {
+		err = c.Channel.Confirm(false)
+		if err != nil {
+			return fmt.Errorf("c.Channel.Confirm: %w", err)
+		}
+	}
+
+	if c.Mandatory {
+		c.Return = c.Channel.NotifyReturn(make(chan amqp.Return, 1))
+	}
+
+	if c.DirectReplyTo {
+		// Direct reply-to requires no-ack mode.
+		c.Delivery, err = c.Channel.Consume(DirectReplyTo, c.consumerTag(), true, false, false, false, nil)
+		if err != nil {
+			return fmt.Errorf("c.Channel.Consume(%s): %w", DirectReplyTo, err)
+		}
+
+		return nil
+	}
+
 	err = c.Channel.ExchangeDeclare(
 		c.ConsumerExchange,
 		"fanout",
@@ -76,7 +375,12 @@ func (c *Connection) connect() error {
 		nil,
 	)
 	if err != nil {
-		return fmt.Errorf("c.Connection.Channel: %w", err)
+		return fmt.Errorf("c.Channel.ExchangeDeclare: %w", err)
+	}
+
+	var args amqp.Table
+	if c.MaxPriority > 0 {
+		args = amqp.Table{"x-max-priority": int32(c.MaxPriority)}
 	}
 
 	queue, err := c.Channel.QueueDeclare(
@@ -85,7 +389,7 @@ func (c *Connection) connect() error {
 		false,
 		true,
 		false,
-		nil,
+		args,
 	)
 	if err != nil {
 		return fmt.Errorf("c.Channel.QueueDeclare: %w", err)
@@ -104,7 +408,7 @@ func (c *Connection) connect() error {
 
 	c.Delivery, err = c.Channel.Consume(
 		queue.Name,
-		"",
+		c.consumerTag(),
 		false,
 		false,
 		false,
@@ -117,3 +421,16 @@ func (c *Connection) connect() error {
 
 	return nil
 }
+
+// consumerTag identifies the only consumer of the channel, see Cancel.
+func (c *Connection) consumerTag() string {
+	return "rmq_rpc-" + c.ConsumerExchange
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(interface{}, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})       {}
+func (nopLogger) Warn(string, ...interface{})       {}
+func (nopLogger) Error(interface{}, ...interface{}) {}
+func (nopLogger) Fatal(interface{}, ...interface{}) {}
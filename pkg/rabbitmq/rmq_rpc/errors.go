@@ -1,15 +1,22 @@
 package rmqrpc
 
-import "errors"
+import (
+	"errors"
 
+	"github.com/evrone/go-clean-template/pkg/rpc"
+)
+
+// Errors and statuses are shared by all RPC transports, so errors.Is works the same on every bus.
 var (
 	// ErrTimeout -.
-	ErrTimeout = errors.New("timeout")
+	ErrTimeout = rpc.ErrTimeout
 	// ErrInternalServer -.
-	ErrInternalServer = errors.New("internal server error")
+	ErrInternalServer = rpc.ErrInternalServer
 	// ErrBadHandler -.
-	ErrBadHandler = errors.New("unregistered handler")
+	ErrBadHandler = rpc.ErrBadHandler
+	// ErrUnroutable is returned for mandatory publishings when no server queue is bound to the exchange.
+	ErrUnroutable = errors.New("unroutable: no server queue bound")
 )
 
 // Success -.
-const Success = "success"
+const Success = rpc.Success
@@ -0,0 +1,31 @@
+package rmqrpc
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers converts AMQP headers to rpc headers, values are formatted as strings.
+func Headers(t amqp.Table) map[string]string {
+	h := make(map[string]string, len(t))
+	for k, v := range t {
+		h[k] = fmt.Sprint(v)
+	}
+
+	return h
+}
+
+// Table converts rpc headers to AMQP headers.
+func Table(h map[string]string) amqp.Table {
+	if len(h) == 0 {
+		return nil
+	}
+
+	t := make(amqp.Table, len(h))
+	for k, v := range h {
+		t[k] = v
+	}
+
+	return t
+}
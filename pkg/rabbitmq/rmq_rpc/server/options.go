@@ -1,6 +1,11 @@
 package server
 
-import "time"
+import (
+	"crypto/tls"
+	"time"
+
+	rmqrpc "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc"
+)
 
 // Option -.
 type Option func(*Server)
@@ -19,9 +24,45 @@ func ConnWaitTime(timeout time.Duration) Option {
 	}
 }
 
+// ConnMaxWaitTime caps the exponential reconnect backoff.
+func ConnMaxWaitTime(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.conn.MaxWaitTime = timeout
+	}
+}
+
+// TLS configures the CA bundle and client certificate for amqps:// URLs, see pkg/tlsconfig.
+func TLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.conn.TLS = cfg
+	}
+}
+
+// ExternalAuth authenticates with SASL EXTERNAL by the TLS client certificate instead of the URL credentials.
+func ExternalAuth(enabled bool) Option {
+	return func(s *Server) {
+		s.conn.ExternalAuth = enabled
+	}
+}
+
 // ConnAttempts -.
 func ConnAttempts(attempts int) Option {
 	return func(s *Server) {
 		s.conn.Attempts = attempts
 	}
 }
+
+// MaxPriority declares the request queue as a priority queue, so client.CallPriority takes effect.
+func MaxPriority(priority uint8) Option {
+	return func(s *Server) {
+		s.conn.MaxPriority = priority
+	}
+}
+
+// OnStateChange is called when the connection is lost, restored, given up once the attempts run out
+// or closed, e.g. to report health.
+func OnStateChange(fn func(rmqrpc.State)) Option {
+	return func(s *Server) {
+		s.conn.OnStateChange = fn
+	}
+}
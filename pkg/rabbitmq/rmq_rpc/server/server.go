@@ -5,31 +5,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/evrone/go-clean-template/pkg/logger"
 	rmqrpc "github.com/evrone/go-clean-template/pkg/rabbitmq/rmq_rpc"
-	"github.com/goccy/go-json"
+	"github.com/evrone/go-clean-template/pkg/rpc"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	_defaultWaitTime = 5 * time.Second
-	_defaultAttempts = 10
-	_defaultTimeout  = 2 * time.Second
+	_defaultWaitTime    = time.Second
+	_defaultMaxWaitTime = 30 * time.Second
+	_defaultAttempts    = 10
+	_defaultTimeout     = 2 * time.Second
 )
 
-// CallHandler -.
-type CallHandler func(*amqp.Delivery) (interface{}, error)
-
 // Server -.
 type Server struct {
 	ctx context.Context
 	eg  *errgroup.Group
 
+	// serveCtx is the context of handlers, canceled once in-flight requests are done on shutdown.
+	serveCtx    context.Context
+	cancelServe context.CancelFunc
+
 	conn   *rmqrpc.Connection
-	router map[string]CallHandler
+	router *rpc.Router
 	stop   chan struct{}
 	notify chan error
 
@@ -39,25 +42,31 @@ type Server struct {
 }
 
 // New -.
-func New(url, serverExchange string, router map[string]CallHandler, l logger.Interface, opts ...Option) (*Server, error) {
+func New(url, serverExchange string, router *rpc.Router, l logger.Interface, opts ...Option) (*Server, error) {
 	group, ctx := errgroup.WithContext(context.Background())
 	group.SetLimit(1) // Run only one goroutine
 
+	serveCtx, cancelServe := context.WithCancel(context.Background())
+
 	cfg := rmqrpc.Config{
-		URL:      url,
-		WaitTime: _defaultWaitTime,
-		Attempts: _defaultAttempts,
+		URL:         url,
+		WaitTime:    _defaultWaitTime,
+		MaxWaitTime: _defaultMaxWaitTime,
+		Attempts:    _defaultAttempts,
+		Logger:      l,
 	}
 
 	s := &Server{
-		ctx:     ctx,
-		eg:      group,
-		conn:    rmqrpc.New(serverExchange, cfg),
-		router:  router,
-		stop:    make(chan struct{}),
-		notify:  make(chan error, 1),
-		timeout: _defaultTimeout,
-		logger:  l,
+		ctx:         ctx,
+		eg:          group,
+		serveCtx:    serveCtx,
+		cancelServe: cancelServe,
+		conn:        rmqrpc.New(serverExchange, cfg),
+		router:      router,
+		stop:        make(chan struct{}),
+		notify:      make(chan error, 1),
+		timeout:     _defaultTimeout,
+		logger:      l,
 	}
 
 	// Custom options
@@ -96,29 +105,46 @@ func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
-// Shutdown -.
+// Shutdown stops consuming, answers the requests already received and then closes the connection.
+// If the consumer cannot be canceled, the connection is closed first and received requests are lost.
 func (s *Server) Shutdown() error {
 	var shutdownErrors []error
 
 	close(s.stop)
 
+	// The server queue is exclusive: requests left unanswered on close are lost
+	err := s.conn.Cancel()
+	if err != nil {
+		s.logger.Error(err, "rmq_rpc server - Server - Shutdown - s.conn.Cancel")
+
+		shutdownErrors = append(shutdownErrors, err)
+
+		// Delivery is only closed with the connection then, serveReceived would wait for it forever.
+		err = s.conn.Close()
+		if err != nil {
+			s.logger.Error(err, "rmq_rpc server - Server - Shutdown - s.conn.Close")
+
+			shutdownErrors = append(shutdownErrors, err)
+		}
+	}
+
 	// Wait for all goroutines to finish and get any error
-	err := s.eg.Wait()
+	err = s.eg.Wait()
 	if err != nil && !errors.Is(err, context.Canceled) {
 		s.logger.Error(err, "rmq_rpc server - Server - Shutdown - s.eg.Wait")
 
 		shutdownErrors = append(shutdownErrors, err)
 	}
 
-	// Close connection
-
-	err = s.conn.Connection.Close()
+	err = s.conn.Close()
 	if err != nil {
-		s.logger.Error(err, "rmq_rpc server - Server - Shutdown - s.Connection.Close")
+		s.logger.Error(err, "rmq_rpc server - Server - Shutdown - s.conn.Close")
 
 		shutdownErrors = append(shutdownErrors, err)
 	}
 
+	s.cancelServe()
+
 	s.logger.Info("rmq_rpc server - Server - Shutdown")
 
 	return errors.Join(shutdownErrors...)
@@ -130,9 +156,23 @@ func (s *Server) handleMessages() error {
 		case <-s.ctx.Done():
 			return s.ctx.Err()
 		case <-s.stop:
+			s.serveReceived()
+
 			return nil
+		case <-s.conn.Done():
+			err := s.reconnect()
+			if err != nil {
+				return err
+			}
 		case d, opened := <-s.conn.Delivery:
 			if !opened {
+				// Closed by Cancel on shutdown, or with the connection.
+				select {
+				case <-s.stop:
+					return nil
+				default:
+				}
+
 				err := s.reconnect()
 				if err != nil {
 					return err
@@ -146,35 +186,48 @@ func (s *Server) handleMessages() error {
 	}
 }
 
-func (s *Server) reconnect() error {
-	return s.conn.AttemptConnect()
+// serveReceived serves the deliveries received before the consumer is canceled, until Delivery is closed.
+func (s *Server) serveReceived() {
+	for d := range s.conn.Delivery {
+		s.serveCall(&d)
+	}
 }
 
-func (s *Server) serveCall(d *amqp.Delivery) {
-	defer s.ack(d, false)
+// reconnect re-declares the exchange and the queue on a new connection. Shutdown interrupts it.
+func (s *Server) reconnect() error {
+	reconnected := make(chan struct{})
+	defer close(reconnected)
 
-	callHandler, ok := s.router[d.Type]
-	if !ok {
-		s.publish(d, nil, rmqrpc.ErrBadHandler.Error())
+	go func() {
+		select {
+		case <-s.stop:
+			s.conn.Close() //nolint:errcheck // closed again on shutdown
+		case <-reconnected:
+		}
+	}()
 
-		return
+	err := s.conn.Reconnect()
+	if err != nil && !errors.Is(err, rmqrpc.ErrClosed) {
+		return fmt.Errorf("rmq_rpc server - Server - reconnect - s.conn.Reconnect: %w", err)
 	}
 
-	response, err := callHandler(d)
-	if err != nil {
-		s.publish(d, nil, rmqrpc.ErrInternalServer.Error())
-
-		s.logger.Error(err, "rmq_rpc server - Server - serveCall - callHandler")
+	return nil
+}
 
-		return
-	}
+func (s *Server) serveCall(d *amqp.Delivery) {
+	defer s.ack(d, false)
 
-	body, err := json.Marshal(response)
+	response, err := s.router.Serve(s.serveCtx, &rpc.Request{
+		Handler:     d.Type,
+		ContentType: d.ContentType,
+		Header:      rmqrpc.Headers(d.Headers),
+		Body:        d.Body,
+	})
 	if err != nil {
-		s.logger.Error(err, "rmq_rpc server - Server - serveCall - json.Marshal")
+		s.logger.Error(err, "rmq_rpc server - Server - serveCall - s.router.Serve")
 	}
 
-	s.publish(d, body, rmqrpc.Success)
+	s.publish(d, response)
 }
 
 func (s *Server) ack(d *amqp.Delivery, multiple bool) {
@@ -184,17 +237,24 @@ func (s *Server) ack(d *amqp.Delivery, multiple bool) {
 	}
 }
 
-func (s *Server) publish(d *amqp.Delivery, body []byte, status string) {
+func (s *Server) publish(d *amqp.Delivery, response *rpc.Response) {
+	// Clients using direct reply-to are addressed by routing key on the default exchange.
+	exchange, key := d.ReplyTo, ""
+	if strings.HasPrefix(d.ReplyTo, rmqrpc.DirectReplyTo) {
+		exchange, key = "", d.ReplyTo
+	}
+
 	err := s.conn.Channel.Publish(
-		d.ReplyTo,
-		"",
+		exchange,
+		key,
 		false,
 		false,
 		amqp.Publishing{
-			ContentType:   "application/json",
+			ContentType:   response.ContentType,
 			CorrelationId: d.CorrelationId,
-			Type:          status,
-			Body:          body,
+			Headers:       rmqrpc.Table(response.Header),
+			Type:          response.Status,
+			Body:          response.Body,
 		},
 	)
 	if err != nil {
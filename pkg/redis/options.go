@@ -1,6 +1,9 @@
 package redis
 
-import "time"
+import (
+	"crypto/tls"
+	"time"
+)
 
 // Option is a functional option for Redis.
 type Option func(*Redis)
@@ -18,3 +21,11 @@ func ConnTimeout(timeout time.Duration) Option {
 		r.connTimeout = timeout
 	}
 }
+
+// TLSConfig connects over TLS with cfg, also for redis:// URLs.
+// The server name defaults to the host of the URL.
+func TLSConfig(cfg *tls.Config) Option {
+	return func(r *Redis) {
+		r.tlsConfig = cfg
+	}
+}
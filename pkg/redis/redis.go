@@ -3,8 +3,10 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -23,6 +25,7 @@ const (
 type Redis struct {
 	connAttempts int
 	connTimeout  time.Duration
+	tlsConfig    *tls.Config
 
 	Client *redis.Client
 }
@@ -48,6 +51,13 @@ func New(url string, opts ...Option) (*Redis, error) {
 	redisOpts.ReadTimeout = _defaultReadTimeout
 	redisOpts.WriteTimeout = _defaultWriteTimeout
 
+	if r.tlsConfig != nil {
+		redisOpts.TLSConfig = r.tlsConfig.Clone()
+		if redisOpts.TLSConfig.ServerName == "" {
+			redisOpts.TLSConfig.ServerName, _, _ = net.SplitHostPort(redisOpts.Addr)
+		}
+	}
+
 	r.Client = redis.NewClient(redisOpts)
 
 	// Retry connection.
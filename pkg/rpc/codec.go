@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+
+	"github.com/goccy/go-json"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Content types of the supported codecs.
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeProtobuf    = "application/x-protobuf"
+	ContentTypeMessagePack = "application/msgpack"
+)
+
+// Codec encodes request and response bodies.
+type Codec interface {
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// ProtoConverter is implemented by results which are not protobuf messages themselves,
+// so the same handler can reply in every codec.
+type ProtoConverter interface {
+	Proto() proto.Message
+}
+
+// Supported codecs.
+var (
+	JSON        Codec = jsonCodec{}
+	Protobuf    Codec = protobufCodec{}
+	MessagePack Codec = msgpackCodec{}
+)
+
+// CodecFor returns the codec of a content type, JSON if it is empty.
+func CodecFor(contentType string) (Codec, error) {
+	if contentType == "" {
+		return JSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrUnsupportedContentType, contentType, err)
+	}
+
+	switch mediaType {
+	case ContentTypeJSON:
+		return JSON, nil
+	case ContentTypeProtobuf:
+		return Protobuf, nil
+	case ContentTypeMessagePack:
+		return MessagePack, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string { return ContentTypeJSON }
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	switch m := v.(type) {
+	case nil:
+		return nil, nil
+	case proto.Message:
+		return proto.Marshal(m)
+	case ProtoConverter:
+		return proto.Marshal(m.Proto())
+	default:
+		return nil, fmt.Errorf("rpc - protobufCodec - Marshal: %T is not a proto.Message", v)
+	}
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("rpc - protobufCodec - Unmarshal: %T is not a proto.Message", v)
+	}
+
+	return proto.Unmarshal(data, m)
+}
+
+// msgpackCodec uses the json struct tags, so field names are the same as in JSON.
+type msgpackCodec struct{}
+
+func (msgpackCodec) ContentType() string { return ContentTypeMessagePack }
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+
+	return dec.Decode(v)
+}
@@ -0,0 +1,97 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type greeting struct {
+	Text string `json:"text"`
+}
+
+func (g greeting) Proto() proto.Message {
+	return wrapperspb.String(g.Text)
+}
+
+func greetRouter() *rpc.Router {
+	router := rpc.NewRouter()
+	router.Handle("v1.greet", func(context.Context, *rpc.Request) (interface{}, error) {
+		return greeting{Text: "hello"}, nil
+	})
+
+	return router
+}
+
+func TestCodecFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		contentType string
+		want        rpc.Codec
+	}{
+		{contentType: "", want: rpc.JSON},
+		{contentType: "application/json; charset=utf-8", want: rpc.JSON},
+		{contentType: "application/x-protobuf", want: rpc.Protobuf},
+		{contentType: "application/msgpack", want: rpc.MessagePack},
+	}
+
+	for _, tt := range tests {
+		codec, err := rpc.CodecFor(tt.contentType)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, codec)
+	}
+
+	_, err := rpc.CodecFor("text/xml")
+	require.ErrorIs(t, err, rpc.ErrUnsupportedContentType)
+}
+
+func TestRouter_RepliesInCallerCodec(t *testing.T) {
+	t.Parallel()
+
+	for _, codec := range []rpc.Codec{rpc.JSON, rpc.MessagePack} {
+		t.Run(codec.ContentType(), func(t *testing.T) {
+			t.Parallel()
+
+			req, err := rpc.NewRequest(codec, "v1.greet", nil)
+			require.NoError(t, err)
+
+			resp, err := greetRouter().Serve(t.Context(), req)
+			require.NoError(t, err)
+			require.Equal(t, codec.ContentType(), resp.ContentType)
+
+			var out greeting
+
+			require.NoError(t, resp.Decode(&out))
+			require.Equal(t, "hello", out.Text)
+		})
+	}
+}
+
+func TestRouter_RepliesInProtobuf(t *testing.T) {
+	t.Parallel()
+
+	req, err := rpc.NewRequest(rpc.Protobuf, "v1.greet", wrapperspb.String("ignored"))
+	require.NoError(t, err)
+
+	resp, err := greetRouter().Serve(t.Context(), req)
+	require.NoError(t, err)
+	require.Equal(t, rpc.ContentTypeProtobuf, resp.ContentType)
+
+	out := &wrapperspb.StringValue{}
+
+	require.NoError(t, resp.Decode(out))
+	require.Equal(t, "hello", out.GetValue())
+}
+
+func TestRouter_RejectsUnsupportedContentType(t *testing.T) {
+	t.Parallel()
+
+	resp, err := greetRouter().Serve(t.Context(), &rpc.Request{Handler: "v1.greet", ContentType: "text/xml"})
+	require.ErrorIs(t, err, rpc.ErrUnsupportedContentType)
+	require.ErrorIs(t, resp.Decode(&greeting{}), rpc.ErrUnsupportedContentType)
+}
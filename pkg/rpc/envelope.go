@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeaderDeadline carries the caller's deadline (RFC 3339), so the server stops working on a call nobody waits for.
+const HeaderDeadline = "Deadline"
+
+// Request is a call received from, or sent to, a message bus.
+// ContentType selects the codec of the body, empty means JSON.
+type Request struct {
+	Handler     string
+	ContentType string
+	Header      map[string]string
+	Body        []byte
+}
+
+// Response is the reply to a Request. Status is Success, StatusError or a transport error (ErrBadHandler, ErrInternalServer).
+// Successful replies use the codec of the request.
+type Response struct {
+	Status      string
+	ContentType string
+	Header      map[string]string
+	Body        []byte
+}
+
+// NewRequest encodes payload with codec into the body of a request for handler. A nil payload leaves the body empty.
+func NewRequest(codec Codec, handler string, payload interface{}) (*Request, error) {
+	req := &Request{
+		Handler:     handler,
+		ContentType: codec.ContentType(),
+		Header:      make(map[string]string),
+	}
+
+	if payload != nil {
+		body, err := codec.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("rpc - NewRequest - codec.Marshal: %w", err)
+		}
+
+		req.Body = body
+	}
+
+	return req, nil
+}
+
+// SetDeadline sends the caller's deadline with the request.
+func (r *Request) SetDeadline(deadline time.Time) {
+	if r.Header == nil {
+		r.Header = make(map[string]string)
+	}
+
+	r.Header[HeaderDeadline] = deadline.UTC().Format(time.RFC3339Nano)
+}
+
+// Deadline returns the caller's deadline, ok is false if the request has none or it is malformed.
+func (r *Request) Deadline() (deadline time.Time, ok bool) {
+	value, found := r.Header[HeaderDeadline]
+	if !found {
+		return time.Time{}, false
+	}
+
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return deadline, true
+}
+
+// Bind decodes the request body into v.
+func (r *Request) Bind(v interface{}) error {
+	codec, err := CodecFor(r.ContentType)
+	if err != nil {
+		return fmt.Errorf("rpc - Request - Bind: %w", err)
+	}
+
+	err = codec.Unmarshal(r.Body, v)
+	if err != nil {
+		return fmt.Errorf("rpc - Request - Bind - codec.Unmarshal: %w", err)
+	}
+
+	return nil
+}
+
+// Decode returns the error encoded in the reply or decodes the body into v.
+func (r *Response) Decode(v interface{}) error {
+	err := r.Err()
+	if err != nil {
+		return err
+	}
+
+	codec, err := CodecFor(r.ContentType)
+	if err != nil {
+		return fmt.Errorf("rpc - Response - Decode: %w", err)
+	}
+
+	err = codec.Unmarshal(r.Body, v)
+	if err != nil {
+		return fmt.Errorf("rpc - Response - Decode - codec.Unmarshal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrTimeout -.
+	ErrTimeout = errors.New("timeout")
+	// ErrInternalServer -.
+	ErrInternalServer = errors.New("internal server error")
+	// ErrBadHandler -.
+	ErrBadHandler = errors.New("unregistered handler")
+	// ErrUnsupportedContentType -.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+const (
+	// Success -.
+	Success = "success"
+	// StatusError marks a reply carrying an Error in the body.
+	StatusError = "error"
+
+	// HeaderErrorCode duplicates the code of an Error reply, so it is visible without decoding the body.
+	HeaderErrorCode = "Error-Code"
+)
+
+// Coder is implemented by application errors which are sent to the caller with their code,
+// e.g. entity.AppError. Other handler errors are reported as ErrInternalServer.
+type Coder interface {
+	error
+	ErrorCode() string
+}
+
+// Detailer is optionally implemented by a Coder to send additional context.
+type Detailer interface {
+	ErrorDetails() map[string]string
+}
+
+// Error is an application error received in a reply.
+type Error struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode -.
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorDetails -.
+func (e *Error) ErrorDetails() map[string]string {
+	return e.Details
+}
+
+// Is reports whether target has the same code, so errors.Is works against the sender's sentinel errors.
+func (e *Error) Is(target error) bool {
+	coder, ok := target.(Coder)
+
+	return ok && e.Code == coder.ErrorCode()
+}
+
+// ErrorResponse encodes a handler error into a reply. The Error body is always JSON.
+// Details of errors which are not a Coder stay on the server.
+func ErrorResponse(err error) *Response {
+	for _, rpcErr := range []error{ErrBadHandler, ErrUnsupportedContentType} {
+		if errors.Is(err, rpcErr) {
+			return &Response{Status: rpcErr.Error(), ContentType: ContentTypeJSON}
+		}
+	}
+
+	var coder Coder
+	if !errors.As(err, &coder) {
+		return &Response{Status: ErrInternalServer.Error(), ContentType: ContentTypeJSON}
+	}
+
+	rpcErr := Error{Code: coder.ErrorCode(), Message: coder.Error()}
+
+	var detailer Detailer
+	if errors.As(err, &detailer) {
+		rpcErr.Details = detailer.ErrorDetails()
+	}
+
+	body, err := json.Marshal(rpcErr)
+	if err != nil {
+		return &Response{Status: ErrInternalServer.Error(), ContentType: ContentTypeJSON}
+	}
+
+	return &Response{
+		Status:      StatusError,
+		ContentType: ContentTypeJSON,
+		Header:      map[string]string{HeaderErrorCode: rpcErr.Code},
+		Body:        body,
+	}
+}
+
+// Err returns the error encoded in the reply, nil on Success.
+func (r *Response) Err() error {
+	switch r.Status {
+	case Success:
+		return nil
+	case ErrBadHandler.Error():
+		return ErrBadHandler
+	case ErrInternalServer.Error():
+		return ErrInternalServer
+	case ErrUnsupportedContentType.Error():
+		return ErrUnsupportedContentType
+	case StatusError:
+		rpcErr := &Error{}
+
+		err := json.Unmarshal(r.Body, rpcErr)
+		if err != nil {
+			return fmt.Errorf("%w: json.Unmarshal: %w", ErrInternalServer, err)
+		}
+
+		return rpcErr
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInternalServer, r.Status)
+	}
+}
@@ -0,0 +1,122 @@
+// Package rpc implements the transport independent part of the RPC servers and clients:
+// envelope, handler signature, router with middleware and error encoding.
+// Message buses (RabbitMQ, NATS) only adapt their messages to Request and Response.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+type responseHeaderKey struct{}
+
+// SetResponseHeader sets a header of the reply to the request served with ctx.
+// It is a no-op outside of Router.Serve.
+func SetResponseHeader(ctx context.Context, key, value string) {
+	header, ok := ctx.Value(responseHeaderKey{}).(map[string]string)
+	if ok {
+		header[key] = value
+	}
+}
+
+// Handler serves a request; the returned value is marshalled into the response body.
+type Handler func(context.Context, *Request) (interface{}, error)
+
+// Middleware wraps a Handler.
+type Middleware func(Handler) Handler
+
+// Router -.
+type Router struct {
+	handlers    map[string]Handler
+	middlewares []Middleware
+}
+
+// NewRouter -.
+func NewRouter() *Router {
+	return &Router{handlers: make(map[string]Handler)}
+}
+
+// Use appends middlewares. The first one added is the outermost.
+func (r *Router) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Handle registers handler under name, e.g. v1.getHistory.
+func (r *Router) Handle(name string, handler Handler) {
+	r.handlers[name] = handler
+}
+
+// Handlers returns the registered handler names in sorted order.
+func (r *Router) Handlers() []string {
+	names := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Call runs the handler registered for req.Handler through the middlewares.
+func (r *Router) Call(ctx context.Context, req *Request) (interface{}, error) {
+	handler, ok := r.handlers[req.Handler]
+	if !ok {
+		handler = func(context.Context, *Request) (interface{}, error) {
+			return nil, fmt.Errorf("%w: %s", ErrBadHandler, req.Handler)
+		}
+	}
+
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+
+	return handler(ctx, req)
+}
+
+// Serve calls the handler and encodes the result into a response with the codec of the request.
+// The handler context ends at the caller's deadline, if the request carries one.
+// The response is always valid; the error is returned for logging only.
+func (r *Router) Serve(ctx context.Context, req *Request) (*Response, error) {
+	header := make(map[string]string)
+
+	if deadline, ok := req.Deadline(); ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	response, err := r.serve(context.WithValue(ctx, responseHeaderKey{}, header), req)
+
+	if response.Header == nil {
+		response.Header = header
+	} else {
+		maps.Copy(response.Header, header)
+	}
+
+	return response, err
+}
+
+func (r *Router) serve(ctx context.Context, req *Request) (*Response, error) {
+	codec, err := CodecFor(req.ContentType)
+	if err != nil {
+		return ErrorResponse(err), err
+	}
+
+	result, err := r.Call(ctx, req)
+	if err != nil {
+		return ErrorResponse(err), err
+	}
+
+	body, err := codec.Marshal(result)
+	if err != nil {
+		err = fmt.Errorf("rpc - Router - Serve - codec.Marshal: %w", err)
+
+		return ErrorResponse(err), err
+	}
+
+	return &Response{Status: Success, ContentType: codec.ContentType(), Body: body}, nil
+}
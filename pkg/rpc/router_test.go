@@ -0,0 +1,172 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errHandler  = errors.New("handler failed")
+	errNotFound = &codedError{code: "NOT_FOUND", message: "resource not found"}
+)
+
+type codedError struct {
+	code    string
+	message string
+	details map[string]string
+}
+
+func (e *codedError) Error() string                   { return e.message }
+func (e *codedError) ErrorCode() string               { return e.code }
+func (e *codedError) ErrorDetails() map[string]string { return e.details }
+
+type echo struct {
+	Text string `json:"text"`
+}
+
+func newRouter() *rpc.Router {
+	router := rpc.NewRouter()
+	router.Handle("v1.echo", func(_ context.Context, req *rpc.Request) (interface{}, error) {
+		var e echo
+
+		err := req.Bind(&e)
+		if err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	})
+	router.Handle("v1.fail", func(context.Context, *rpc.Request) (interface{}, error) {
+		return nil, errHandler
+	})
+	router.Handle("v1.find", func(context.Context, *rpc.Request) (interface{}, error) {
+		return nil, fmt.Errorf("repo - Find: %w", &codedError{
+			code:    errNotFound.code,
+			message: errNotFound.message,
+			details: map[string]string{"id": "42"},
+		})
+	})
+
+	return router
+}
+
+func TestRouter_ServeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	req, err := rpc.NewRequest(rpc.JSON, "v1.echo", echo{Text: "hello"})
+	require.NoError(t, err)
+
+	resp, err := newRouter().Serve(t.Context(), req)
+	require.NoError(t, err)
+	require.Equal(t, rpc.Success, resp.Status)
+
+	var out echo
+
+	require.NoError(t, resp.Decode(&out))
+	require.Equal(t, "hello", out.Text)
+}
+
+func TestRouter_ServeEncodesErrors(t *testing.T) {
+	t.Parallel()
+
+	router := newRouter()
+
+	tests := []struct {
+		handler string
+		want    error
+	}{
+		{handler: "v1.unknown", want: rpc.ErrBadHandler},
+		{handler: "v1.fail", want: rpc.ErrInternalServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.handler, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := router.Serve(t.Context(), &rpc.Request{Handler: tt.handler})
+			require.Error(t, err)
+			require.Equal(t, tt.want.Error(), resp.Status)
+			require.ErrorIs(t, resp.Decode(&echo{}), tt.want)
+		})
+	}
+}
+
+func TestRouter_ServeEncodesCodedErrors(t *testing.T) {
+	t.Parallel()
+
+	resp, err := newRouter().Serve(t.Context(), &rpc.Request{Handler: "v1.find"})
+	require.Error(t, err)
+	require.Equal(t, rpc.StatusError, resp.Status)
+	require.Equal(t, "NOT_FOUND", resp.Header[rpc.HeaderErrorCode])
+
+	err = resp.Decode(&echo{})
+	require.ErrorIs(t, err, errNotFound)
+	require.NotErrorIs(t, err, rpc.ErrInternalServer)
+
+	var rpcErr *rpc.Error
+
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, "resource not found", rpcErr.Message)
+	require.Equal(t, map[string]string{"id": "42"}, rpcErr.Details)
+}
+
+func TestRouter_MiddlewareOrder(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+
+	trace := func(name string) rpc.Middleware {
+		return func(next rpc.Handler) rpc.Handler {
+			return func(ctx context.Context, req *rpc.Request) (interface{}, error) {
+				order = append(order, name)
+
+				return next(ctx, req)
+			}
+		}
+	}
+
+	router := newRouter()
+	router.Use(trace("outer"), trace("inner"))
+
+	_, err := router.Call(t.Context(), &rpc.Request{Handler: "v1.unknown"})
+	require.ErrorIs(t, err, rpc.ErrBadHandler)
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.Equal(t, []string{"v1.echo", "v1.fail", "v1.find"}, router.Handlers())
+}
+
+func TestRouter_ServeAppliesCallerDeadline(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+
+	var got time.Time
+
+	router := rpc.NewRouter()
+	router.Handle("v1.deadline", func(ctx context.Context, _ *rpc.Request) (interface{}, error) {
+		got, _ = ctx.Deadline()
+
+		return nil, nil //nolint:nilnil // empty reply
+	})
+
+	req, err := rpc.NewRequest(rpc.JSON, "v1.deadline", nil)
+	require.NoError(t, err)
+
+	req.SetDeadline(deadline)
+
+	_, err = router.Serve(t.Context(), req)
+	require.NoError(t, err)
+	require.True(t, deadline.Equal(got), "deadline %s, got %s", deadline, got)
+
+	// A malformed deadline is ignored.
+	req.Header[rpc.HeaderDeadline] = "soon"
+
+	_, err = router.Serve(t.Context(), req)
+	require.NoError(t, err)
+	require.True(t, got.IsZero())
+}
@@ -0,0 +1,31 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+)
+
+type clientSubjectKey struct{}
+
+// VerifiedSubject returns the subject of the verified client certificate, e.g. "CN=client,O=Example",
+// or an empty string if the connection is not TLS or the client certificate was not verified.
+func VerifiedSubject(state *tls.ConnectionState) string {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return state.VerifiedChains[0][0].Subject.String()
+}
+
+// WithClientSubject returns a copy of ctx carrying the verified client certificate subject.
+func WithClientSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, clientSubjectKey{}, subject)
+}
+
+// ClientSubject returns the verified client certificate subject stored by the HTTP and gRPC servers,
+// or an empty string.
+func ClientSubject(ctx context.Context) string {
+	subject, _ := ctx.Value(clientSubjectKey{}).(string)
+
+	return subject
+}
@@ -0,0 +1,75 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+type options struct {
+	caFile             string
+	certFile           string
+	keyFile            string
+	serverName         string
+	insecureSkipVerify bool
+
+	clientAuth     *tls.ClientAuthType
+	reloadInterval time.Duration
+	onReload       func(error)
+}
+
+// Option -.
+type Option func(*options)
+
+// CAFile verifies the peer certificate against the PEM encoded CA bundle: the server certificate
+// for Client (instead of the system roots), the client certificate for Server.
+func CAFile(file string) Option {
+	return func(o *options) {
+		o.caFile = file
+	}
+}
+
+// Certificate presents the PEM encoded certificate and key: the client certificate for Client,
+// e.g. for mutual TLS or SASL EXTERNAL, the server certificate for Server.
+func Certificate(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// ServerName overrides the host name the server certificate is verified against.
+func ServerName(name string) Option {
+	return func(o *options) {
+		o.serverName = name
+	}
+}
+
+// InsecureSkipVerify disables server certificate verification, for local development only.
+func InsecureSkipVerify(skip bool) Option {
+	return func(o *options) {
+		o.insecureSkipVerify = skip
+	}
+}
+
+// ClientAuth overrides the client certificate policy of Server, e.g. tls.VerifyClientCertIfGiven.
+// By default client certificates are required with CAFile and not requested without it.
+func ClientAuth(auth tls.ClientAuthType) Option {
+	return func(o *options) {
+		o.clientAuth = &auth
+	}
+}
+
+// ReloadInterval sets how often Server checks the certificate files for changes.
+func ReloadInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reloadInterval = interval
+	}
+}
+
+// OnReload is called after Server re-reads changed certificate files, with the error if the new
+// certificate could not be loaded and the previous one is still served.
+func OnReload(fn func(error)) Option {
+	return func(o *options) {
+		o.onReload = fn
+	}
+}
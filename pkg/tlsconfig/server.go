@@ -0,0 +1,140 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const _defaultReloadInterval = 10 * time.Second
+
+// ErrNoCertificate is returned by Server without a certificate and key, see Certificate.
+var ErrNoCertificate = errors.New("tlsconfig - server certificate and key files are required")
+
+// Server returns a server TLS config with TLS 1.2 as the minimum version.
+// The certificate (see Certificate) is re-read when its files change, checked at most
+// once per ReloadInterval on new connections, so rotated certificates are served without a restart.
+// With CAFile, client certificates are required and verified against the CA bundle, see ClientAuth.
+func Server(opts ...Option) (*tls.Config, error) {
+	o := &options{reloadInterval: _defaultReloadInterval}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.certFile == "" || o.keyFile == "" {
+		return nil, ErrNoCertificate
+	}
+
+	r := &reloader{certFile: o.certFile, keyFile: o.keyFile, interval: o.reloadInterval, onReload: o.onReload}
+
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+
+	if o.caFile != "" {
+		cfg.ClientCAs, err = CertPool(o.caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	if o.clientAuth != nil {
+		cfg.ClientAuth = *o.clientAuth
+	}
+
+	return cfg, nil
+}
+
+type reloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	onReload func(error)
+
+	mu      sync.Mutex
+	checked time.Time
+	modTime time.Time
+	cert    *tls.Certificate
+}
+
+func (r *reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < r.interval {
+		return r.cert, nil
+	}
+
+	r.checked = time.Now()
+
+	modTime, err := r.latestModTime()
+	if err == nil && modTime.Equal(r.modTime) {
+		return r.cert, nil
+	}
+
+	if err == nil {
+		err = r.loadLocked()
+	}
+
+	// A half-written or removed file keeps the previous certificate in use.
+	if r.onReload != nil {
+		r.onReload(err)
+	}
+
+	return r.cert, nil
+}
+
+func (r *reloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checked = time.Now()
+
+	return r.loadLocked()
+}
+
+func (r *reloader) loadLocked() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tlsconfig - Server - tls.LoadX509KeyPair: %w", err)
+	}
+
+	r.cert = &cert
+	r.modTime = modTime
+
+	return nil
+}
+
+func (r *reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("tlsconfig - Server - os.Stat: %w", err)
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,154 @@
+package tlsconfig_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig/tlstest"
+	"github.com/stretchr/testify/require"
+)
+
+// handshake connects to a server using cfg and returns the server certificate and the verified client subject.
+func handshake(t *testing.T, cfg *tls.Config, client *tls.Config) (string, string, error) {
+	t.Helper()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	subject := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tlsConn := conn.(*tls.Conn) //nolint:forcetypeassert // tls.Listen
+		_ = tlsConn.Handshake()
+		state := tlsConn.ConnectionState()
+		subject <- tlsconfig.VerifiedSubject(&state)
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return "", "", err
+	}
+	defer conn.Close()
+
+	// TLS 1.3 reports a rejected client certificate on the first read.
+	_, err = conn.Read(make([]byte, 1))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", "", err
+	}
+
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, <-subject, nil
+}
+
+func copyFile(t *testing.T, src, dst string, modTime time.Time) {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+	require.NoError(t, os.Chtimes(dst, modTime, modTime))
+}
+
+func TestServer_ReloadsCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	first, second := ca.Issue(t, "first"), ca.Issue(t, "second")
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	copyFile(t, first.CertFile, certFile, time.Now().Add(-time.Minute))
+	copyFile(t, first.KeyFile, keyFile, time.Now().Add(-time.Minute))
+
+	reloads := make(chan error, 1)
+
+	cfg, err := tlsconfig.Server(
+		tlsconfig.Certificate(certFile, keyFile),
+		tlsconfig.ReloadInterval(0),
+		tlsconfig.OnReload(func(err error) { reloads <- err }),
+	)
+	require.NoError(t, err)
+
+	client := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.Pool(), ServerName: "localhost"}
+
+	name, _, err := handshake(t, cfg, client)
+	require.NoError(t, err)
+	require.Equal(t, "first", name)
+
+	copyFile(t, second.CertFile, certFile, time.Now())
+	copyFile(t, second.KeyFile, keyFile, time.Now())
+
+	name, _, err = handshake(t, cfg, client)
+	require.NoError(t, err)
+	require.Equal(t, "second", name)
+	require.NoError(t, <-reloads)
+
+	// A broken key keeps the previous certificate.
+	require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+	require.NoError(t, os.Chtimes(keyFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+	name, _, err = handshake(t, cfg, client)
+	require.NoError(t, err)
+	require.Equal(t, "second", name)
+	require.Error(t, <-reloads)
+}
+
+func TestServer_VerifiesClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	server, client := ca.Issue(t, "server"), ca.Issue(t, "client")
+
+	cfg, err := tlsconfig.Server(tlsconfig.Certificate(server.CertFile, server.KeyFile), tlsconfig.CAFile(ca.CertFile))
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+
+	withCert := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      ca.Pool(),
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{client.TLS},
+	}
+
+	_, subject, err := handshake(t, cfg, withCert)
+	require.NoError(t, err)
+	require.Equal(t, "CN=client", subject)
+
+	// A certificate from another CA is rejected.
+	withCert.Certificates = []tls.Certificate{tlstest.NewCA(t).Issue(t, "client").TLS}
+
+	_, _, err = handshake(t, cfg, withCert)
+	require.Error(t, err)
+}
+
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := tlsconfig.Server()
+	require.ErrorIs(t, err, tlsconfig.ErrNoCertificate)
+
+	_, err = tlsconfig.Server(tlsconfig.Certificate("missing.crt", "missing.key"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestClientSubject(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, tlsconfig.ClientSubject(t.Context()))
+	require.Equal(t, "CN=client", tlsconfig.ClientSubject(tlsconfig.WithClientSubject(t.Context(), "CN=client")))
+	require.Empty(t, tlsconfig.VerifiedSubject(nil))
+	require.Empty(t, tlsconfig.VerifiedSubject(&tls.ConnectionState{}))
+}
@@ -0,0 +1,110 @@
+package tlsconfig_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evrone/go-clean-template/pkg/tlsconfig"
+	"github.com/evrone/go-clean-template/pkg/tlsconfig/tlstest"
+	"github.com/stretchr/testify/require"
+)
+
+// serve accepts one mutual TLS connection and returns the verified client common name.
+func serve(t *testing.T, server tls.Certificate, clientCAs *x509.CertPool) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{server},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { ln.Close() })
+
+	subject := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tlsConn := conn.(*tls.Conn) //nolint:forcetypeassert // tls.Listen
+		if tlsConn.Handshake() != nil {
+			subject <- ""
+
+			return
+		}
+
+		subject <- tlsConn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}()
+
+	return ln.Addr().String(), subject
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	client := ca.Issue(t, "client")
+	addr, subject := serve(t, ca.Issue(t, "server").TLS, ca.Pool())
+
+	cfg, err := tlsconfig.Client(
+		tlsconfig.CAFile(ca.CertFile),
+		tlsconfig.Certificate(client.CertFile, client.KeyFile),
+		tlsconfig.ServerName("localhost"),
+	)
+	require.NoError(t, err)
+
+	conn, err := tls.Dial("tcp", addr, cfg)
+	require.NoError(t, err)
+	require.NoError(t, conn.Handshake())
+	require.NoError(t, conn.Close())
+
+	require.Equal(t, "client", <-subject)
+}
+
+func TestClient_UnknownCA(t *testing.T) {
+	t.Parallel()
+
+	ca := tlstest.NewCA(t)
+	addr, _ := serve(t, ca.Issue(t, "server").TLS, ca.Pool())
+
+	cfg, err := tlsconfig.Client(tlsconfig.CAFile(tlstest.NewCA(t).CertFile), tlsconfig.ServerName("localhost"))
+	require.NoError(t, err)
+
+	_, err = tls.Dial("tcp", addr, cfg)
+
+	var unknownAuthority x509.UnknownAuthorityError
+
+	require.ErrorAs(t, err, &unknownAuthority)
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	empty := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+
+	_, err := tlsconfig.Client(tlsconfig.CAFile(empty))
+	require.ErrorIs(t, err, tlsconfig.ErrNoCertificates)
+
+	_, err = tlsconfig.Client(tlsconfig.CAFile(filepath.Join(dir, "missing.pem")))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = tlsconfig.Client(tlsconfig.Certificate(tlstest.NewCA(t).CertFile, ""))
+	require.ErrorIs(t, err, tlsconfig.ErrCertKeyPair)
+
+	cfg, err := tlsconfig.Client()
+	require.NoError(t, err)
+	require.Nil(t, cfg.RootCAs)
+	require.Empty(t, cfg.Certificates)
+}
@@ -0,0 +1,132 @@
+// Package tlstest generates a throwaway CA and certificates for TLS tests.
+package tlstest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// CA is a self-signed certificate authority stored in a test temp dir.
+type CA struct {
+	// CertFile is the PEM encoded CA certificate.
+	CertFile string
+
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// Cert is a certificate issued by CA, valid for 127.0.0.1 and localhost.
+type Cert struct {
+	CertFile string
+	KeyFile  string
+	TLS      tls.Certificate
+}
+
+// NewCA -.
+func NewCA(t testing.TB) *CA {
+	t.Helper()
+
+	ca := &CA{dir: t.TempDir()}
+	ca.cert, ca.key, ca.CertFile, _ = ca.generate(t, "ca", true)
+
+	return ca
+}
+
+// Issue returns a server and client certificate for commonName signed by ca.
+func (ca *CA) Issue(t testing.TB, commonName string) Cert {
+	t.Helper()
+
+	_, _, certFile, keyFile := ca.generate(t, commonName, false)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("tlstest - Issue - tls.LoadX509KeyPair: %s", err)
+	}
+
+	return Cert{CertFile: certFile, KeyFile: keyFile, TLS: cert}
+}
+
+// Pool returns a pool trusting only ca.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	return pool
+}
+
+func (ca *CA) generate(t testing.TB, commonName string, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("tlstest - ecdsa.GenerateKey: %s", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64)) //nolint:mnd // 64-bit serial
+	if err != nil {
+		t.Fatalf("tlstest - rand.Int: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)}, //nolint:mnd // loopback
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	parent, parentKey := ca.cert, ca.key
+	if isCA {
+		template.IsCA = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("tlstest - x509.CreateCertificate: %s", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("tlstest - x509.MarshalECPrivateKey: %s", err)
+	}
+
+	certFile := filepath.Join(ca.dir, commonName+".crt")
+	keyFile := filepath.Join(ca.dir, commonName+".key")
+
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("tlstest - x509.ParseCertificate: %s", err)
+	}
+
+	return cert, key, certFile, keyFile
+}
+
+func writePEM(t testing.TB, file, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatalf("tlstest - os.WriteFile: %s", err)
+	}
+}